@@ -0,0 +1,169 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package analysis
+
+import (
+	"reflect"
+	"sort"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// AttributeChange is a change to a single attribute value of a resource.
+type AttributeChange struct {
+	// Path is the formatted attribute path, e.g. "tags.env" or "disk[0].size".
+	Path string
+
+	// Before and After are the values before and after the change, nil if the
+	// value is absent or null. Both are nil if Sensitive is true, and After is
+	// nil if Unknown is true.
+	Before interface{}
+	After  interface{}
+
+	Sensitive bool
+	Unknown   bool
+}
+
+// AttributeChanges compares the before and after values of a change and
+// returns a change per differing leaf value, ordered by path. Objects and
+// lists are compared element by element, while sensitive or unknown values
+// are reported at the level they are marked at.
+func AttributeChanges(change *tfjson.Change) []AttributeChange {
+	if change == nil {
+		return nil
+	}
+
+	// compare attributes of created or deleted objects against an empty one
+	before, after := change.Before, change.After
+	if before == nil {
+		before = map[string]interface{}{}
+	}
+	if after == nil && change.AfterUnknown != true {
+		after = map[string]interface{}{}
+	}
+
+	var changes []AttributeChange
+	walkChange(&changes, nil, before, after, change.AfterUnknown, change.BeforeSensitive, change.AfterSensitive)
+	return changes
+}
+
+func walkChange(changes *[]AttributeChange, path []interface{}, before, after, unknown, beforeSensitive, afterSensitive interface{}) {
+	if unknown == true {
+		c := AttributeChange{
+			Path:      FormatPath(path),
+			Unknown:   true,
+			Sensitive: containsTrue(beforeSensitive) || containsTrue(afterSensitive),
+		}
+		if !c.Sensitive {
+			c.Before = before
+		}
+		*changes = append(*changes, c)
+		return
+	}
+
+	if beforeSensitive == true || afterSensitive == true {
+		if !reflect.DeepEqual(before, after) {
+			*changes = append(*changes, AttributeChange{
+				Path:      FormatPath(path),
+				Sensitive: true,
+			})
+		}
+		return
+	}
+
+	bm, bIsMap := before.(map[string]interface{})
+	am, aIsMap := after.(map[string]interface{})
+	if bIsMap && aIsMap {
+		keys := map[string]bool{}
+		for k := range bm {
+			keys[k] = true
+		}
+		for k := range am {
+			keys[k] = true
+		}
+		if um, ok := unknown.(map[string]interface{}); ok {
+			for k := range um {
+				keys[k] = true
+			}
+		}
+		names := make([]string, 0, len(keys))
+		for k := range keys {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+
+		for _, k := range names {
+			walkChange(changes, appendStep(path, k), bm[k], am[k], mapValue(unknown, k), mapValue(beforeSensitive, k), mapValue(afterSensitive, k))
+		}
+		return
+	}
+
+	bl, bIsList := before.([]interface{})
+	al, aIsList := after.([]interface{})
+	if bIsList && aIsList {
+		n := len(bl)
+		if len(al) > n {
+			n = len(al)
+		}
+		for i := 0; i < n; i++ {
+			var b, a interface{}
+			if i < len(bl) {
+				b = bl[i]
+			}
+			if i < len(al) {
+				a = al[i]
+			}
+			walkChange(changes, appendStep(path, i), b, a, listValue(unknown, i), listValue(beforeSensitive, i), listValue(afterSensitive, i))
+		}
+		return
+	}
+
+	// values of different shapes, e.g. null and an object, may still contain
+	// nested sensitive values
+	sensitive := containsTrue(beforeSensitive) || containsTrue(afterSensitive)
+
+	if containsTrue(unknown) {
+		// partially unknown values, e.g. a list of unknown length
+		change := AttributeChange{
+			Path:      FormatPath(path),
+			Unknown:   true,
+			Sensitive: sensitive,
+		}
+		if !sensitive {
+			change.Before = before
+			change.After = after
+		}
+		*changes = append(*changes, change)
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		if sensitive {
+			*changes = append(*changes, AttributeChange{Path: FormatPath(path), Sensitive: true})
+			return
+		}
+		*changes = append(*changes, AttributeChange{
+			Path:   FormatPath(path),
+			Before: before,
+			After:  after,
+		})
+	}
+}
+
+func appendStep(path []interface{}, step interface{}) []interface{} {
+	return append(path[:len(path):len(path)], step)
+}
+
+func mapValue(v interface{}, key string) interface{} {
+	m, _ := v.(map[string]interface{})
+	return m[key]
+}
+
+func listValue(v interface{}, i int) interface{} {
+	l, _ := v.([]interface{})
+	if i < len(l) {
+		return l[i]
+	}
+	return nil
+}
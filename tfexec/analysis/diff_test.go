@@ -0,0 +1,95 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+func TestAttributeChanges(t *testing.T) {
+	change := &tfjson.Change{
+		Actions: tfjson.Actions{tfjson.ActionUpdate},
+		Before: map[string]interface{}{
+			"ami":      "ami-123",
+			"password": "hunter2",
+			"tags":     map[string]interface{}{"env": "prod", "owner": "a"},
+			"disk":     []interface{}{map[string]interface{}{"size": float64(10)}},
+			"id":       "i-1",
+			"arn":      "arn:1",
+		},
+		After: map[string]interface{}{
+			"ami":      "ami-123",
+			"password": "hunter3",
+			"tags":     map[string]interface{}{"env": "dev", "owner": "a", "team": "b"},
+			"disk":     []interface{}{map[string]interface{}{"size": float64(20)}, map[string]interface{}{"size": float64(5)}},
+			"id":       "i-1",
+		},
+		AfterUnknown:    map[string]interface{}{"arn": true},
+		BeforeSensitive: map[string]interface{}{"password": true},
+		AfterSensitive:  map[string]interface{}{"password": true},
+	}
+
+	expected := []AttributeChange{
+		{Path: "arn", Before: "arn:1", Unknown: true},
+		{Path: "disk[0].size", Before: float64(10), After: float64(20)},
+		{Path: "disk[1]", After: map[string]interface{}{"size": float64(5)}},
+		{Path: "password", Sensitive: true},
+		{Path: "tags.env", Before: "prod", After: "dev"},
+		{Path: "tags.team", After: "b"},
+	}
+
+	if diff := cmp.Diff(expected, AttributeChanges(change)); diff != "" {
+		t.Fatalf("unexpected attribute changes: %s", diff)
+	}
+
+	if changes := AttributeChanges(nil); changes != nil {
+		t.Fatalf("expected no changes, got %#v", changes)
+	}
+}
+
+func TestAttributeChanges_create(t *testing.T) {
+	change := &tfjson.Change{
+		Actions: tfjson.Actions{tfjson.ActionCreate},
+		After:   map[string]interface{}{"ami": "ami-123"},
+		AfterUnknown: map[string]interface{}{
+			"id": true,
+		},
+	}
+
+	expected := []AttributeChange{
+		{Path: "ami", After: "ami-123"},
+		{Path: "id", Unknown: true},
+	}
+
+	if diff := cmp.Diff(expected, AttributeChanges(change)); diff != "" {
+		t.Fatalf("unexpected attribute changes: %s", diff)
+	}
+}
+
+func TestAttributeChanges_nestedSensitive(t *testing.T) {
+	change := &tfjson.Change{
+		Actions: tfjson.Actions{tfjson.ActionUpdate},
+		Before: map[string]interface{}{
+			"old": map[string]interface{}{"token": "abc", "user": "a"},
+		},
+		After: map[string]interface{}{
+			"creds": map[string]interface{}{"password": "hunter2", "user": "b"},
+			"old":   nil,
+		},
+		BeforeSensitive: map[string]interface{}{"old": map[string]interface{}{"token": true}},
+		AfterSensitive:  map[string]interface{}{"creds": map[string]interface{}{"password": true}},
+	}
+
+	expected := []AttributeChange{
+		{Path: "creds", Sensitive: true},
+		{Path: "old", Sensitive: true},
+	}
+
+	if diff := cmp.Diff(expected, AttributeChanges(change)); diff != "" {
+		t.Fatalf("unexpected attribute changes: %s", diff)
+	}
+}
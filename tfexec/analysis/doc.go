@@ -0,0 +1,8 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package analysis provides helpers for interpreting the structured plan
+// returned by (*tfexec.Terraform).ShowPlanFile, such as grouping resource
+// changes by action, summarizing them per module and rendering them as text
+// or Markdown without invoking Terraform again.
+package analysis
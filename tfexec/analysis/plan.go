@@ -0,0 +1,373 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Action is the effective action of a single change, with replacements
+// (delete and create in either order) collapsed into ActionReplace.
+type Action string
+
+const (
+	ActionNoOp    Action = "no-op"
+	ActionCreate  Action = "create"
+	ActionRead    Action = "read"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"
+	ActionReplace Action = "replace"
+	ActionForget  Action = "forget"
+)
+
+// Actions lists all actions in the order they are usually presented.
+var Actions = []Action{
+	ActionCreate,
+	ActionUpdate,
+	ActionReplace,
+	ActionDelete,
+	ActionForget,
+	ActionRead,
+	ActionNoOp,
+}
+
+// ActionOf returns the effective action of the given change actions.
+func ActionOf(actions tfjson.Actions) Action {
+	switch {
+	case actions.Create():
+		return ActionCreate
+	case actions.Read():
+		return ActionRead
+	case actions.Update():
+		return ActionUpdate
+	case actions.Delete():
+		return ActionDelete
+	case actions.Replace():
+		return ActionReplace
+	case actions.Forget():
+		return ActionForget
+	}
+	return ActionNoOp
+}
+
+// ReplaceReason describes why a resource is being replaced.
+//
+// The JSON plan format exposes the reason Terraform chose to replace a
+// resource, but it is not decoded by terraform-json, so it is derived from
+// the rest of the plan instead.
+type ReplaceReason string
+
+const (
+	// ReplaceBecauseCannotUpdate means that at least one changed attribute
+	// cannot be updated in-place, see ResourceChange.ReplacePaths.
+	ReplaceBecauseCannotUpdate ReplaceReason = "cannot_update"
+
+	// ReplaceBecauseTainted means that the resource is tainted in the prior state.
+	ReplaceBecauseTainted ReplaceReason = "tainted"
+
+	// ReplaceByRequest means that replacement was requested, either via the
+	// -replace flag or by a replace_triggered_by lifecycle argument.
+	ReplaceByRequest ReplaceReason = "requested"
+)
+
+// ResourceChange is the analysis of a single resource change in a plan.
+type ResourceChange struct {
+	Address       string
+	ModuleAddress string
+	Type          string
+	Action        Action
+
+	// ReplaceReason is set if Action is ActionReplace.
+	ReplaceReason ReplaceReason
+
+	// ReplacePaths lists the attributes forcing replacement, e.g. "disk[0].size".
+	ReplacePaths []string
+
+	// Sensitive is true if the change touches any sensitive value.
+	Sensitive bool
+
+	// ImportID is the ID the resource is imported with, if Importing is true.
+	Importing bool
+	ImportID  string
+
+	// DeferredReason is the reason the change was deferred, if Deferred is true.
+	Deferred       bool
+	DeferredReason string
+
+	// Change is the raw change as found in the plan.
+	Change *tfjson.ResourceChange
+}
+
+// OutputChange is the analysis of a single output change in a plan.
+type OutputChange struct {
+	Name      string
+	Action    Action
+	Sensitive bool
+
+	// Change is the raw change as found in the plan.
+	Change *tfjson.Change
+}
+
+// ModuleSummary counts resource changes by action within a single module.
+type ModuleSummary struct {
+	// Address is the module address, empty for the root module.
+	Address string
+	Counts  map[Action]int
+}
+
+// PlanSummary is the analysis of a plan, see Summarize.
+type PlanSummary struct {
+	// Changes groups all (non-deferred) resource changes by action.
+	Changes map[Action][]*ResourceChange
+
+	// Deferred lists the resource changes deferred to a later plan.
+	Deferred []*ResourceChange
+
+	// Imports lists the resource changes which import a resource.
+	Imports []*ResourceChange
+
+	// Sensitive lists the resource changes touching sensitive values.
+	Sensitive []*ResourceChange
+
+	// Outputs lists all output changes, sorted by name.
+	Outputs []*OutputChange
+
+	// Modules summarizes resource changes per module address, where the root
+	// module is keyed by the empty string.
+	Modules map[string]*ModuleSummary
+}
+
+// Summarize analyses the given plan.
+func Summarize(plan *tfjson.Plan) *PlanSummary {
+	s := &PlanSummary{
+		Changes: map[Action][]*ResourceChange{},
+		Modules: map[string]*ModuleSummary{},
+	}
+	if plan == nil {
+		return s
+	}
+
+	tainted := taintedResources(plan.PriorState)
+
+	for _, rc := range plan.ResourceChanges {
+		c := newResourceChange(rc, tainted)
+		if c == nil {
+			continue
+		}
+
+		s.Changes[c.Action] = append(s.Changes[c.Action], c)
+
+		if c.Importing {
+			s.Imports = append(s.Imports, c)
+		}
+		if c.Sensitive {
+			s.Sensitive = append(s.Sensitive, c)
+		}
+
+		ms, ok := s.Modules[c.ModuleAddress]
+		if !ok {
+			ms = &ModuleSummary{
+				Address: c.ModuleAddress,
+				Counts:  map[Action]int{},
+			}
+			s.Modules[c.ModuleAddress] = ms
+		}
+		ms.Counts[c.Action]++
+	}
+
+	for _, dc := range plan.DeferredChanges {
+		c := newResourceChange(dc.ResourceChange, tainted)
+		if c == nil {
+			continue
+		}
+		c.Deferred = true
+		c.DeferredReason = dc.Reason
+		s.Deferred = append(s.Deferred, c)
+	}
+
+	for name, oc := range plan.OutputChanges {
+		if oc == nil {
+			continue
+		}
+		s.Outputs = append(s.Outputs, &OutputChange{
+			Name:      name,
+			Action:    ActionOf(oc.Actions),
+			Sensitive: containsTrue(oc.BeforeSensitive) || containsTrue(oc.AfterSensitive),
+			Change:    oc,
+		})
+	}
+	sort.Slice(s.Outputs, func(i, j int) bool {
+		return s.Outputs[i].Name < s.Outputs[j].Name
+	})
+
+	return s
+}
+
+// Count returns the number of resource changes with the given action.
+func (s *PlanSummary) Count(action Action) int {
+	return len(s.Changes[action])
+}
+
+// HasChanges returns true if any resource or output change is not a no-op.
+func (s *PlanSummary) HasChanges() bool {
+	for action, changes := range s.Changes {
+		if action != ActionNoOp && len(changes) > 0 {
+			return true
+		}
+	}
+	for _, oc := range s.Outputs {
+		if oc.Action != ActionNoOp {
+			return true
+		}
+	}
+	return false
+}
+
+// ModuleAddresses returns the addresses of all modules with resource changes,
+// sorted with the root module first.
+func (s *PlanSummary) ModuleAddresses() []string {
+	addrs := make([]string, 0, len(s.Modules))
+	for addr := range s.Modules {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	return addrs
+}
+
+func newResourceChange(rc *tfjson.ResourceChange, tainted map[string]bool) *ResourceChange {
+	if rc == nil || rc.Change == nil {
+		return nil
+	}
+
+	c := &ResourceChange{
+		Address:       rc.Address,
+		ModuleAddress: rc.ModuleAddress,
+		Type:          rc.Type,
+		Action:        ActionOf(rc.Change.Actions),
+		ReplacePaths:  formatPaths(rc.Change.ReplacePaths),
+		Sensitive:     containsTrue(rc.Change.BeforeSensitive) || containsTrue(rc.Change.AfterSensitive),
+		Change:        rc,
+	}
+
+	if rc.Change.Importing != nil {
+		c.Importing = true
+		c.ImportID = rc.Change.Importing.ID
+	}
+
+	if c.Action == ActionReplace {
+		switch {
+		case tainted[rc.Address]:
+			c.ReplaceReason = ReplaceBecauseTainted
+		case len(c.ReplacePaths) > 0:
+			c.ReplaceReason = ReplaceBecauseCannotUpdate
+		default:
+			c.ReplaceReason = ReplaceByRequest
+		}
+	}
+
+	return c
+}
+
+// taintedResources returns the addresses of all tainted resources in state.
+func taintedResources(state *tfjson.State) map[string]bool {
+	tainted := map[string]bool{}
+	if state == nil || state.Values == nil {
+		return tainted
+	}
+
+	var walk func(m *tfjson.StateModule)
+	walk = func(m *tfjson.StateModule) {
+		if m == nil {
+			return
+		}
+		for _, r := range m.Resources {
+			if r.Tainted {
+				tainted[r.Address] = true
+			}
+		}
+		for _, cm := range m.ChildModules {
+			walk(cm)
+		}
+	}
+	walk(state.Values.RootModule)
+
+	return tainted
+}
+
+// formatPaths formats the attribute paths found in replace_paths, each of
+// which is a list of attribute names (strings) and indices (numbers or strings).
+func formatPaths(paths []interface{}) []string {
+	var formatted []string
+	for _, p := range paths {
+		steps, ok := p.([]interface{})
+		if !ok {
+			continue
+		}
+		formatted = append(formatted, FormatPath(steps))
+	}
+	return formatted
+}
+
+// FormatPath formats an attribute path as used in the JSON plan output, e.g.
+// ["disk", 0, "size"] becomes disk[0].size. Steps which are not valid
+// identifiers, such as map keys containing spaces, are rendered in brackets.
+func FormatPath(steps []interface{}) string {
+	var b strings.Builder
+	for i, step := range steps {
+		switch step := step.(type) {
+		case string:
+			if !isIdentifier(step) {
+				fmt.Fprintf(&b, "[%q]", step)
+			} else if i == 0 {
+				b.WriteString(step)
+			} else {
+				b.WriteString("." + step)
+			}
+		default:
+			fmt.Fprintf(&b, "[%v]", step)
+		}
+	}
+	return b.String()
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r == '-' || r >= '0' && r <= '9'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// containsTrue reports whether v, a sensitivity or unknown-ness marker from
+// the JSON plan, is true or contains any true value.
+func containsTrue(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case []interface{}:
+		for _, e := range v {
+			if containsTrue(e) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for _, e := range v {
+			if containsTrue(e) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package analysis
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+func loadPlan(t *testing.T) *tfjson.Plan {
+	t.Helper()
+
+	b, err := os.ReadFile("testdata/plan.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var plan tfjson.Plan
+	err = json.Unmarshal(b, &plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &plan
+}
+
+func addresses(changes []*ResourceChange) []string {
+	addrs := []string{}
+	for _, c := range changes {
+		addrs = append(addrs, c.Address)
+	}
+	return addrs
+}
+
+func TestSummarize(t *testing.T) {
+	s := Summarize(loadPlan(t))
+
+	t.Run("changes by action", func(t *testing.T) {
+		actual := map[Action][]string{}
+		for action, changes := range s.Changes {
+			actual[action] = addresses(changes)
+		}
+
+		expected := map[Action][]string{
+			ActionCreate:  {"null_resource.new"},
+			ActionUpdate:  {"aws_instance.web"},
+			ActionReplace: {"aws_db_instance.main", "module.app.aws_instance.tainted"},
+			ActionDelete:  {"module.app.aws_s3_bucket.old"},
+			ActionRead:    {"data.aws_ami.ubuntu"},
+			ActionNoOp:    {"aws_iam_role.imported"},
+		}
+
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Fatalf("mismatch (-expected +actual):\n%s", diff)
+		}
+
+		if !s.HasChanges() {
+			t.Fatal("expected plan to have changes")
+		}
+	})
+
+	t.Run("replace reasons", func(t *testing.T) {
+		replaced := s.Changes[ActionReplace]
+
+		if replaced[0].ReplaceReason != ReplaceBecauseCannotUpdate {
+			t.Fatalf("expected %q, got %q", ReplaceBecauseCannotUpdate, replaced[0].ReplaceReason)
+		}
+		if diff := cmp.Diff([]string{"engine"}, replaced[0].ReplacePaths); diff != "" {
+			t.Fatalf("mismatch (-expected +actual):\n%s", diff)
+		}
+
+		if replaced[1].ReplaceReason != ReplaceBecauseTainted {
+			t.Fatalf("expected %q, got %q", ReplaceBecauseTainted, replaced[1].ReplaceReason)
+		}
+	})
+
+	t.Run("flags", func(t *testing.T) {
+		if diff := cmp.Diff([]string{"aws_db_instance.main"}, addresses(s.Sensitive)); diff != "" {
+			t.Fatalf("sensitive mismatch (-expected +actual):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{"aws_iam_role.imported"}, addresses(s.Imports)); diff != "" {
+			t.Fatalf("imports mismatch (-expected +actual):\n%s", diff)
+		}
+		if s.Imports[0].ImportID != "imported-role" {
+			t.Fatalf("expected import ID %q, got %q", "imported-role", s.Imports[0].ImportID)
+		}
+		if diff := cmp.Diff([]string{"module.later.null_resource.foo"}, addresses(s.Deferred)); diff != "" {
+			t.Fatalf("deferred mismatch (-expected +actual):\n%s", diff)
+		}
+		if s.Deferred[0].DeferredReason != "provider_config_unknown" {
+			t.Fatalf("expected deferred reason %q, got %q", "provider_config_unknown", s.Deferred[0].DeferredReason)
+		}
+	})
+
+	t.Run("outputs", func(t *testing.T) {
+		if len(s.Outputs) != 2 {
+			t.Fatalf("expected 2 outputs, got %d", len(s.Outputs))
+		}
+		if s.Outputs[0].Name != "db_password" || s.Outputs[0].Action != ActionUpdate || !s.Outputs[0].Sensitive {
+			t.Fatalf("unexpected output change: %#v", s.Outputs[0])
+		}
+		if s.Outputs[1].Name != "ip" || s.Outputs[1].Action != ActionCreate || s.Outputs[1].Sensitive {
+			t.Fatalf("unexpected output change: %#v", s.Outputs[1])
+		}
+	})
+
+	t.Run("modules", func(t *testing.T) {
+		if diff := cmp.Diff([]string{"", "module.app"}, s.ModuleAddresses()); diff != "" {
+			t.Fatalf("mismatch (-expected +actual):\n%s", diff)
+		}
+
+		expected := map[Action]int{
+			ActionReplace: 1,
+			ActionDelete:  1,
+		}
+		if diff := cmp.Diff(expected, s.Modules["module.app"].Counts); diff != "" {
+			t.Fatalf("mismatch (-expected +actual):\n%s", diff)
+		}
+	})
+}
+
+func TestFormatPath(t *testing.T) {
+	for _, c := range []struct {
+		steps    []interface{}
+		expected string
+	}{
+		{[]interface{}{"engine"}, "engine"},
+		{[]interface{}{"disk", json.Number("0"), "size"}, "disk[0].size"},
+		{[]interface{}{"tags", "Cost Center"}, `tags["Cost Center"]`},
+	} {
+		t.Run(c.expected, func(t *testing.T) {
+			actual := FormatPath(c.steps)
+			if actual != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSummarize_nil(t *testing.T) {
+	s := Summarize(nil)
+	if s.HasChanges() {
+		t.Fatal("expected no changes")
+	}
+}
@@ -0,0 +1,375 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"reflect"
+	"sort"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// RenderFormat is the output format of Render.
+type RenderFormat string
+
+const (
+	// RenderText renders plain text using the same symbols as Terraform.
+	RenderText RenderFormat = "text"
+
+	// RenderMarkdown renders GitHub-flavored Markdown, with each module in a
+	// collapsible section, suitable for pull request comments.
+	RenderMarkdown RenderFormat = "markdown"
+)
+
+const (
+	sensitiveValue = "(sensitive value)"
+	unknownValue   = "(known after apply)"
+)
+
+// RenderOptions configures Render.
+type RenderOptions struct {
+	// Format defaults to RenderText.
+	Format RenderFormat
+
+	// MaxBytes, if greater than zero, caps the size of the rendered plan.
+	// Sections which do not fit are rendered with their summary only, so the
+	// output remains well-formed. Those summaries and a closing note are
+	// always included and may exceed the cap slightly.
+	MaxBytes int
+}
+
+// Render renders a compact diff of the given plan, showing only changed
+// attributes of each resource. Sensitive values are always masked.
+func Render(plan *tfjson.Plan, opts RenderOptions) string {
+	s := Summarize(plan)
+	r := &renderer{
+		markdown: opts.Format == RenderMarkdown,
+	}
+
+	var b strings.Builder
+	b.WriteString(r.summaryLine(s))
+	b.WriteString("\n")
+
+	var sections []string
+	var headers []string
+	for _, addr := range s.ModuleAddresses() {
+		body := r.module(s, addr)
+		if body == "" {
+			continue
+		}
+		headers = append(headers, r.moduleHeader(s, addr))
+		sections = append(sections, body)
+	}
+	if outputs := r.outputs(s.Outputs); outputs != "" {
+		headers = append(headers, r.sectionHeader("Outputs"))
+		sections = append(sections, outputs)
+	}
+
+	omitted := 0
+	for i := range sections {
+		section := r.section(headers[i], sections[i])
+		if opts.MaxBytes > 0 && b.Len()+len(section) > opts.MaxBytes {
+			section = r.section(headers[i], "")
+			omitted++
+		}
+		b.WriteString("\n")
+		b.WriteString(section)
+	}
+
+	if omitted > 0 {
+		fmt.Fprintf(&b, "\nDetails of %d sections omitted to fit within %d bytes.\n", omitted, opts.MaxBytes)
+	}
+
+	return b.String()
+}
+
+type renderer struct {
+	markdown bool
+}
+
+func (r *renderer) summaryLine(s *PlanSummary) string {
+	if !s.HasChanges() && len(s.Deferred) == 0 {
+		return r.bold("No changes.")
+	}
+
+	var parts []string
+	if n := len(s.Imports); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d to import", n))
+	}
+	parts = append(parts,
+		fmt.Sprintf("%d to add", s.Count(ActionCreate)+s.Count(ActionReplace)),
+		fmt.Sprintf("%d to change", s.Count(ActionUpdate)),
+		fmt.Sprintf("%d to destroy", s.Count(ActionDelete)+s.Count(ActionReplace)),
+	)
+	if n := s.Count(ActionForget); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d to forget", n))
+	}
+	if n := len(s.Deferred); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d deferred", n))
+	}
+
+	return r.bold("Plan: " + strings.Join(parts, ", ") + ".")
+}
+
+func (r *renderer) bold(s string) string {
+	if r.markdown {
+		return "**" + s + "**"
+	}
+	return s
+}
+
+func (r *renderer) moduleHeader(s *PlanSummary, addr string) string {
+	name := addr
+	if name == "" {
+		name = "(root module)"
+	}
+
+	var counts []string
+	imports := 0
+	for _, c := range s.Imports {
+		if c.ModuleAddress == addr {
+			imports++
+		}
+	}
+	if imports > 0 {
+		counts = append(counts, fmt.Sprintf("%d import", imports))
+	}
+
+	ms := s.Modules[addr]
+	for _, a := range Actions {
+		if a == ActionNoOp || ms.Counts[a] == 0 {
+			continue
+		}
+		counts = append(counts, fmt.Sprintf("%d %s", ms.Counts[a], a))
+	}
+
+	return r.sectionHeader(name) + " (" + strings.Join(counts, ", ") + ")"
+}
+
+func (r *renderer) sectionHeader(name string) string {
+	if r.markdown {
+		// addresses may contain e.g. string keys like ["<x>"]
+		return "<code>" + html.EscapeString(name) + "</code>"
+	}
+	return name
+}
+
+func (r *renderer) section(header, body string) string {
+	if !r.markdown {
+		if body == "" {
+			return header + ": details omitted\n"
+		}
+		return header + ":\n" + body
+	}
+
+	var b strings.Builder
+	b.WriteString("<details><summary>" + header + "</summary>\n\n")
+	if body == "" {
+		b.WriteString("Details omitted.\n")
+	} else {
+		b.WriteString("```diff\n" + body + "```\n")
+	}
+	b.WriteString("\n</details>\n")
+	return b.String()
+}
+
+// module renders the changes to the resources of a single module, sorted by
+// address. No-op changes are skipped unless they import a resource.
+func (r *renderer) module(s *PlanSummary, addr string) string {
+	var changes []*ResourceChange
+	for _, a := range Actions {
+		for _, c := range s.Changes[a] {
+			if a == ActionNoOp && !c.Importing {
+				continue
+			}
+			if c.ModuleAddress == addr {
+				changes = append(changes, c)
+			}
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Address < changes[j].Address
+	})
+
+	var b strings.Builder
+	for _, c := range changes {
+		b.WriteString(r.resourceChange(c))
+	}
+	return b.String()
+}
+
+func (r *renderer) resourceChange(c *ResourceChange) string {
+	var b strings.Builder
+
+	header := c.Address
+	switch {
+	case c.Importing && c.ImportID != "":
+		header += fmt.Sprintf(" (import id %q)", c.ImportID)
+	case c.Importing:
+		header += " (import)"
+	}
+	if c.ReplaceReason != "" {
+		header += fmt.Sprintf(" (replace: %s)", c.ReplaceReason)
+	}
+	b.WriteString(r.symbol(c.Action) + " " + header + "\n")
+
+	if c.Action == ActionDelete || c.Action == ActionForget {
+		return b.String()
+	}
+
+	ch := c.Change.Change
+	for _, line := range attributeDiff(ch.Before, ch.After, ch.AfterUnknown, ch.BeforeSensitive, ch.AfterSensitive) {
+		b.WriteString(r.indent() + line + "\n")
+	}
+
+	return b.String()
+}
+
+func (r *renderer) outputs(outputs []*OutputChange) string {
+	var b strings.Builder
+	for _, oc := range outputs {
+		if oc.Action == ActionNoOp {
+			continue
+		}
+		line := r.symbol(oc.Action) + " " + oc.Name
+		if oc.Action != ActionDelete {
+			ch := oc.Change
+			line += " = " + valueDiff(ch.Before, ch.After, ch.AfterUnknown, ch.BeforeSensitive, ch.AfterSensitive, oc.Action == ActionCreate)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// symbol returns the symbol Terraform uses for the action. In Markdown, the
+// symbols are chosen so the lines are highlighted in a diff code block.
+func (r *renderer) symbol(a Action) string {
+	if r.markdown {
+		switch a {
+		case ActionCreate:
+			return "+"
+		case ActionDelete:
+			return "-"
+		case ActionUpdate, ActionReplace:
+			return "!"
+		default:
+			return "#"
+		}
+	}
+
+	switch a {
+	case ActionCreate:
+		return "+"
+	case ActionUpdate:
+		return "~"
+	case ActionReplace:
+		return "-/+"
+	case ActionDelete:
+		return "-"
+	case ActionRead:
+		return "<="
+	case ActionForget:
+		return "."
+	}
+	return " "
+}
+
+func (r *renderer) indent() string {
+	if r.markdown {
+		// keep attribute lines from being highlighted
+		return "      "
+	}
+	return "    "
+}
+
+// attributeDiff returns one line per changed top-level attribute, sorted by
+// name.
+func attributeDiff(before, after, afterUnknown, beforeSensitive, afterSensitive interface{}) []string {
+	bm, _ := before.(map[string]interface{})
+	am, _ := after.(map[string]interface{})
+	um, _ := afterUnknown.(map[string]interface{})
+	bsm, _ := beforeSensitive.(map[string]interface{})
+	asm, _ := afterSensitive.(map[string]interface{})
+
+	keys := map[string]bool{}
+	for k := range bm {
+		keys[k] = true
+	}
+	for k := range am {
+		keys[k] = true
+	}
+	for k := range um {
+		keys[k] = true
+	}
+
+	names := make([]string, 0, len(keys))
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var lines []string
+	for _, k := range names {
+		bv, inBefore := bm[k]
+		av := am[k]
+		unknown := containsTrue(um[k])
+
+		created := before == nil || !inBefore
+		if created && av == nil && !unknown {
+			continue
+		}
+		if !created && !unknown && reflect.DeepEqual(bv, av) {
+			continue
+		}
+
+		lines = append(lines, k+": "+valueDiff(bv, av, um[k], bsm[k], asm[k], created))
+	}
+
+	return lines
+}
+
+// valueDiff renders a single changed value as "before -> after", or just the
+// new value if it did not exist before.
+func valueDiff(before, after, afterUnknown, beforeSensitive, afterSensitive interface{}, created bool) string {
+	var a string
+	switch {
+	case containsTrue(afterSensitive):
+		a = sensitiveValue
+	case afterUnknown == true:
+		a = unknownValue
+	case containsTrue(afterUnknown):
+		a = renderValue(after) + " " + unknownValue
+	default:
+		a = renderValue(after)
+	}
+
+	if created {
+		return a
+	}
+
+	b := renderValue(before)
+	if containsTrue(beforeSensitive) {
+		b = sensitiveValue
+	}
+
+	return b + " -> " + a
+}
+
+func renderValue(v interface{}) string {
+	if v == nil {
+		return "null"
+	}
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
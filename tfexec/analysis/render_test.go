@@ -0,0 +1,102 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+func TestRender(t *testing.T) {
+	plan := loadPlan(t)
+
+	t.Run("text", func(t *testing.T) {
+		expected := `Plan: 1 to import, 3 to add, 1 to change, 3 to destroy, 1 deferred.
+
+(root module) (1 import, 1 create, 1 update, 1 replace, 1 read):
+-/+ aws_db_instance.main (replace: cannot_update)
+    engine: "mysql" -> "postgres"
+  aws_iam_role.imported (import id "imported-role")
+~ aws_instance.web
+    instance_type: "t3.micro" -> "t3.small"
+<= data.aws_ami.ubuntu
+    id: (known after apply)
++ null_resource.new
+    id: (known after apply)
+
+module.app (1 replace, 1 delete):
+-/+ module.app.aws_instance.tainted (replace: tainted)
+- module.app.aws_s3_bucket.old
+
+Outputs:
+~ db_password = (sensitive value) -> (sensitive value)
++ ip = "10.0.0.1"
+`
+		if diff := cmp.Diff(expected, Render(plan, RenderOptions{})); diff != "" {
+			t.Fatalf("unexpected rendering: %s", diff)
+		}
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		out := Render(plan, RenderOptions{Format: RenderMarkdown})
+
+		for _, s := range []string{
+			"**Plan: 1 to import, 3 to add, 1 to change, 3 to destroy, 1 deferred.**\n",
+			"<details><summary><code>module.app</code> (1 replace, 1 delete)</summary>\n\n```diff\n! module.app.aws_instance.tainted (replace: tainted)\n- module.app.aws_s3_bucket.old\n```\n\n</details>\n",
+			"! db_password = (sensitive value) -> (sensitive value)\n",
+		} {
+			if !strings.Contains(out, s) {
+				t.Fatalf("expected output to contain %q, got:\n%s", s, out)
+			}
+		}
+		if n := strings.Count(out, "<details>"); n != 3 {
+			t.Fatalf("expected 3 sections, got %d", n)
+		}
+	})
+
+	t.Run("markdown escaping", func(t *testing.T) {
+		plan := &tfjson.Plan{
+			ResourceChanges: []*tfjson.ResourceChange{
+				{
+					Address:       `module.app["<x>&"].aws_s3_bucket.b`,
+					ModuleAddress: `module.app["<x>&"]`,
+					Mode:          tfjson.ManagedResourceMode,
+					Type:          "aws_s3_bucket",
+					Name:          "b",
+					Change:        &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+				},
+			},
+		}
+
+		out := Render(plan, RenderOptions{Format: RenderMarkdown})
+		expected := `<summary><code>module.app[&#34;&lt;x&gt;&amp;&#34;]</code> (1 create)</summary>`
+		if !strings.Contains(out, expected) {
+			t.Fatalf("expected output to contain %q, got:\n%s", expected, out)
+		}
+	})
+
+	t.Run("size cap", func(t *testing.T) {
+		out := Render(plan, RenderOptions{Format: RenderMarkdown, MaxBytes: 400})
+
+		if strings.Contains(out, "aws_db_instance.main") {
+			t.Fatalf("expected root module details to be omitted, got:\n%s", out)
+		}
+		if strings.Count(out, "<details>") != strings.Count(out, "</details>") {
+			t.Fatalf("expected balanced sections, got:\n%s", out)
+		}
+		if !strings.HasSuffix(out, "Details of 2 sections omitted to fit within 400 bytes.\n") {
+			t.Fatalf("expected note on omitted sections, got:\n%s", out)
+		}
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		out := Render(nil, RenderOptions{})
+		if out != "No changes.\n" {
+			t.Fatalf("unexpected rendering: %q", out)
+		}
+	})
+}
@@ -16,11 +16,14 @@ type applyConfig struct {
 	backup        string
 	destroy       bool
 	dirOrPlan     string
+	guard         *ChangeGuard
 	lock          bool
 
 	// LockTimeout must be a string with time unit, e.g. '10s'
 	lockTimeout  string
 	parallelism  int
+	policies     []PolicyEvaluator
+	policyReport *PolicyReport
 	reattachInfo ReattachInfo
 	refresh      bool
 	refreshOnly  bool
@@ -98,6 +101,18 @@ func (opt *DirOrPlanOption) configureApply(conf *applyConfig) {
 	conf.dirOrPlan = opt.path
 }
 
+func (opt *GuardOption) configureApply(conf *applyConfig) {
+	conf.guard = opt.guard
+}
+
+func (opt *PolicyOption) configureApply(conf *applyConfig) {
+	conf.policies = append(conf.policies, opt.evaluators...)
+}
+
+func (opt *PolicyReportOutOption) configureApply(conf *applyConfig) {
+	conf.policyReport = opt.report
+}
+
 func (opt *ReattachOption) configureApply(conf *applyConfig) {
 	conf.reattachInfo = opt.info
 }
@@ -111,7 +126,16 @@ func (opt *AllowDeferralOption) configureApply(conf *applyConfig) {
 }
 
 // Apply represents the terraform apply subcommand.
+//
+// If a ChangeGuard or policies are set, the plan is checked against them
+// before applying, see ChangeGuard and PolicyEvaluator.
 func (tf *Terraform) Apply(ctx context.Context, opts ...ApplyOption) error {
+	opts, cleanup, err := tf.checkedApplyOptions(ctx, opts)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
 	cmd, err := tf.applyCmd(ctx, opts...)
 	if err != nil {
 		return err
@@ -130,6 +154,12 @@ func (tf *Terraform) ApplyJSON(ctx context.Context, w io.Writer, opts ...ApplyOp
 		return fmt.Errorf("terraform apply -json was added in 0.15.3: %w", err)
 	}
 
+	opts, cleanup, err := tf.checkedApplyOptions(ctx, opts)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
 	tf.SetStdout(w)
 
 	cmd, err := tf.applyJSONCmd(ctx, opts...)
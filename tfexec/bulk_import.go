@@ -0,0 +1,414 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// ImportSpec describes a single resource to import with BulkImport.
+type ImportSpec struct {
+	// Address is the resource address to import to, e.g. aws_instance.web or
+	// module.app.aws_instance.web["a"].
+	Address string
+
+	// ID is the import ID of the remote object. Exactly one of ID and
+	// Identity must be set.
+	ID string
+
+	// Identity is the resource identity of the remote object, as declared by
+	// the provider. Importing by identity requires Terraform 1.12 or later.
+	Identity map[string]interface{}
+}
+
+// BulkImportResult is the result of BulkImport.
+type BulkImportResult struct {
+	// GeneratedConfig is the configuration generated for the imported
+	// resources which had none, empty if all of them were already configured.
+	GeneratedConfig string
+
+	// Plan is the plan importing the resources.
+	Plan *tfjson.Plan
+
+	// Applied is true if the plan was applied.
+	Applied bool
+}
+
+type bulkImportConfig struct {
+	apply             bool
+	generateConfigOut string
+	lock              bool
+	lockTimeout       string
+	parallelism       int
+	reattachInfo      ReattachInfo
+	vars              []string
+	varFiles          []string
+
+	// only set by Import, which requires existing configuration and
+	// supports the legacy local state flags
+	backup         string
+	generateConfig bool
+	state          string
+	stateOut       string
+
+	// stateOnly refuses to apply imports which would also change the
+	// imported objects, as the import subcommand never does
+	stateOnly bool
+}
+
+var defaultBulkImportOptions = bulkImportConfig{
+	apply:          false,
+	generateConfig: true,
+	lock:           true,
+	lockTimeout:    "0s",
+	parallelism:    10,
+}
+
+// BulkImportOption represents options used in the BulkImport method.
+type BulkImportOption interface {
+	configureBulkImport(*bulkImportConfig)
+}
+
+func (opt *ApplyImportsOption) configureBulkImport(conf *bulkImportConfig) {
+	conf.apply = opt.apply
+}
+
+func (opt *GenerateConfigOutOption) configureBulkImport(conf *bulkImportConfig) {
+	conf.generateConfigOut = opt.path
+}
+
+func (opt *LockOption) configureBulkImport(conf *bulkImportConfig) {
+	conf.lock = opt.lock
+}
+
+func (opt *LockTimeoutOption) configureBulkImport(conf *bulkImportConfig) {
+	conf.lockTimeout = opt.timeout
+}
+
+func (opt *ParallelismOption) configureBulkImport(conf *bulkImportConfig) {
+	conf.parallelism = opt.parallelism
+}
+
+func (opt *ReattachOption) configureBulkImport(conf *bulkImportConfig) {
+	conf.reattachInfo = opt.info
+}
+
+func (opt *VarOption) configureBulkImport(conf *bulkImportConfig) {
+	conf.vars = append(conf.vars, opt.assignment)
+}
+
+func (opt *VarFileOption) configureBulkImport(conf *bulkImportConfig) {
+	conf.varFiles = append(conf.varFiles, opt.path)
+}
+
+// BulkImport imports the given resources using import blocks, as introduced
+// in Terraform 1.5.
+//
+// The import blocks are written to a temporary file in the working directory
+// and a plan is created with -generate-config-out, so configuration is
+// generated for any resource which has none. The temporary file is always
+// removed afterwards. Until then, commands of other instances using the same
+// working directory, e.g. clones returned by ForWorkspace, wait for it to be
+// removed.
+//
+// By default, the generated configuration is returned and not kept, and the
+// plan is not applied. With ApplyImports(true) the plan is applied, which
+// requires a GenerateConfigOut path in the working directory to keep the
+// generated configuration at. The plan is then targeted at the imported
+// resources, and it is not applied if it changes any other resource, e.g. a
+// dependency of one of them.
+//
+// If planning fails, a result with the configuration generated for the
+// resources which could be planned is returned along with the error.
+func (tf *Terraform) BulkImport(ctx context.Context, specs []ImportSpec, opts ...BulkImportOption) (*BulkImportResult, error) {
+	c := defaultBulkImportOptions
+	for _, o := range opts {
+		o.configureBulkImport(&c)
+	}
+
+	return tf.bulkImport(ctx, specs, c)
+}
+
+func (tf *Terraform) bulkImport(ctx context.Context, specs []ImportSpec, c bulkImportConfig) (*BulkImportResult, error) {
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no resources to import")
+	}
+	if c.apply && c.generateConfig && c.generateConfigOut == "" {
+		return nil, fmt.Errorf("applying imports requires a GenerateConfigOut path to keep the generated configuration")
+	}
+	if c.generateConfig && c.generateConfigOut != "" {
+		err := tf.checkInWorkingDir(c.generateConfigOut)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GenerateConfigOut path: %w", err)
+		}
+	}
+
+	err := tf.compatible(ctx, tf1_5_0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("import blocks were added in Terraform 1.5.0: %w", err)
+	}
+
+	for _, spec := range specs {
+		if spec.Identity != nil {
+			err := tf.compatible(ctx, tf1_12_0, nil)
+			if err != nil {
+				return nil, fmt.Errorf("importing by identity was added in Terraform 1.12.0: %w", err)
+			}
+			break
+		}
+	}
+
+	src, err := importBlocks(specs)
+	if err != nil {
+		return nil, err
+	}
+
+	// other instances using the working directory, e.g. clones returned by
+	// ForWorkspace, must not load the import blocks
+	ctx, unlock := tf.lockWorkingDir(ctx)
+	defer unlock()
+
+	importFile, err := os.CreateTemp(tf.workingDir, "tfexec-import-*.tf")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(importFile.Name())
+
+	_, err = importFile.Write(src)
+	if closeErr := importFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "tfexec-import")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	planPath := filepath.Join(tmpDir, "tfplan")
+
+	planOpts := []PlanOption{
+		Out(planPath),
+		Lock(c.lock),
+		LockTimeout(c.lockTimeout),
+		Parallelism(c.parallelism),
+	}
+
+	// the generated configuration must be part of the root module in order
+	// to be planned, so it is written to the working directory
+	var generatePath string
+	if c.generateConfig {
+		generatePath = c.generateConfigOut
+		if generatePath == "" {
+			generatePath = strings.TrimSuffix(importFile.Name(), ".tf") + "-generated.tf"
+			defer os.Remove(generatePath)
+		} else if !filepath.IsAbs(generatePath) {
+			generatePath = filepath.Join(tf.workingDir, generatePath)
+		}
+		planOpts = append(planOpts, GenerateConfigOut(generatePath))
+	}
+
+	if c.reattachInfo != nil {
+		planOpts = append(planOpts, Reattach(c.reattachInfo))
+	}
+	if c.state != "" {
+		planOpts = append(planOpts, State(c.state))
+	}
+	for _, v := range c.vars {
+		planOpts = append(planOpts, Var(v))
+	}
+	for _, vf := range c.varFiles {
+		planOpts = append(planOpts, VarFile(vf))
+	}
+	if c.apply {
+		// unrelated pending changes must not be applied along with the imports
+		for _, spec := range specs {
+			planOpts = append(planOpts, Target(spec.Address))
+		}
+	}
+
+	_, planErr := tf.Plan(ctx, planOpts...)
+
+	result := &BulkImportResult{}
+
+	if generatePath != "" {
+		generated, err := os.ReadFile(generatePath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) && planErr == nil {
+			return nil, err
+		}
+		result.GeneratedConfig = string(generated)
+	}
+
+	if planErr != nil {
+		return result, planErr
+	}
+
+	var showOpts []ShowOption
+	if c.reattachInfo != nil {
+		showOpts = append(showOpts, Reattach(c.reattachInfo))
+	}
+
+	result.Plan, err = tf.ShowPlanFile(ctx, planPath, showOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.apply {
+		return result, nil
+	}
+
+	err = checkImportOnly(result.Plan, specs, !c.stateOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	applyOpts := []ApplyOption{
+		DirOrPlan(planPath),
+		Lock(c.lock),
+		LockTimeout(c.lockTimeout),
+		Parallelism(c.parallelism),
+	}
+	if c.backup != "" {
+		applyOpts = append(applyOpts, Backup(c.backup))
+	}
+	if c.reattachInfo != nil {
+		applyOpts = append(applyOpts, Reattach(c.reattachInfo))
+	}
+	if c.state != "" {
+		applyOpts = append(applyOpts, State(c.state))
+	}
+	if c.stateOut != "" {
+		applyOpts = append(applyOpts, StateOut(c.stateOut))
+	}
+
+	err = tf.Apply(ctx, applyOpts...)
+	if err != nil {
+		return nil, err
+	}
+	result.Applied = true
+
+	return result, nil
+}
+
+// checkInWorkingDir returns an error unless path, which is relative to the
+// working directory unless absolute, is a file directly in the working
+// directory, i.e. part of the root module.
+func (tf *Terraform) checkInWorkingDir(path string) error {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(tf.workingDir, path)
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	dir, err := filepath.Abs(tf.workingDir)
+	if err != nil {
+		return err
+	}
+	if filepath.Dir(path) != dir {
+		return fmt.Errorf("%s is not in the working directory %s", path, dir)
+	}
+	return nil
+}
+
+// checkImportOnly returns an error if plan changes any resource other than
+// by importing it to the address of one of specs. Unless changeImported is
+// true, the imported objects must not be changed either.
+func checkImportOnly(plan *tfjson.Plan, specs []ImportSpec, changeImported bool) error {
+	addresses := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		addresses[spec.Address] = true
+	}
+
+	var changed []string
+	for _, rc := range plan.ResourceChanges {
+		if rc.Change == nil || rc.Change.Actions.NoOp() || rc.Change.Actions.Read() {
+			continue
+		}
+		if rc.Change.Importing != nil && addresses[rc.Address] && changeImported {
+			continue
+		}
+		changed = append(changed, rc.Address)
+	}
+
+	if len(changed) > 0 {
+		return fmt.Errorf("refusing to apply imports, the plan also changes %s", strings.Join(changed, ", "))
+	}
+	return nil
+}
+
+// ListResourceImportSpec returns an ImportSpec importing a resource found by
+// `terraform query` to the given address, by its identity. See also Query.
+func ListResourceImportSpec(address string, found tfjson.ListResourceFoundData) ImportSpec {
+	return ImportSpec{
+		Address:  address,
+		Identity: found.Identity,
+	}
+}
+
+// importBlocks returns the source of an import block for each spec.
+func importBlocks(specs []ImportSpec) ([]byte, error) {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+
+	for i, spec := range specs {
+		if spec.Address == "" {
+			return nil, fmt.Errorf("import %d: address cannot be empty", i)
+		}
+		if (spec.ID == "") == (spec.Identity == nil) {
+			return nil, fmt.Errorf("import of %s: exactly one of ID and Identity must be set", spec.Address)
+		}
+		if spec.Identity != nil && len(spec.Identity) == 0 {
+			return nil, fmt.Errorf("import of %s: identity cannot be empty", spec.Address)
+		}
+
+		to, diags := hclsyntax.ParseTraversalAbs([]byte(spec.Address), "", hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("import of %s: invalid address: %w", spec.Address, diags)
+		}
+
+		if i > 0 {
+			body.AppendNewline()
+		}
+		block := body.AppendNewBlock("import", nil).Body()
+		block.SetAttributeTraversal("to", to)
+
+		if spec.Identity == nil {
+			block.SetAttributeValue("id", cty.StringVal(spec.ID))
+			continue
+		}
+
+		raw, err := json.Marshal(spec.Identity)
+		if err != nil {
+			return nil, fmt.Errorf("import of %s: %w", spec.Address, err)
+		}
+		ty, err := ctyjson.ImpliedType(raw)
+		if err != nil {
+			return nil, fmt.Errorf("import of %s: %w", spec.Address, err)
+		}
+		identity, err := ctyjson.Unmarshal(raw, ty)
+		if err != nil {
+			return nil, fmt.Errorf("import of %s: %w", spec.Address, err)
+		}
+		block.SetAttributeValue("identity", identity)
+	}
+
+	return f.Bytes(), nil
+}
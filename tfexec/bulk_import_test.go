@@ -0,0 +1,136 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+func TestImportBlocks(t *testing.T) {
+	t.Run("id and identity", func(t *testing.T) {
+		src, err := importBlocks([]ImportSpec{
+			{Address: "aws_instance.web", ID: "i-123"},
+			{Address: `module.app.aws_instance.web["a"]`, Identity: map[string]interface{}{"id": "i-456", "region": "eu-west-1", "account": float64(123)}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := `import {
+  to = aws_instance.web
+  id = "i-123"
+}
+
+import {
+  to = module.app.aws_instance.web["a"]
+  identity = {
+    account = 123
+    id      = "i-456"
+    region  = "eu-west-1"
+  }
+}
+`
+		if diff := cmp.Diff(expected, string(src)); diff != "" {
+			t.Fatalf("unexpected import blocks: %s", diff)
+		}
+	})
+
+	for _, c := range []struct {
+		name string
+		spec ImportSpec
+	}{
+		{"missing address", ImportSpec{ID: "i-123"}},
+		{"missing id", ImportSpec{Address: "aws_instance.web"}},
+		{"id and identity", ImportSpec{Address: "aws_instance.web", ID: "i-123", Identity: map[string]interface{}{"id": "i-123"}}},
+		{"invalid address", ImportSpec{Address: "aws_instance.web[", ID: "i-123"}},
+		{"empty identity", ImportSpec{Address: "aws_instance.web", Identity: map[string]interface{}{}}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := importBlocks([]ImportSpec{c.spec})
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
+
+func TestCheckInWorkingDir(t *testing.T) {
+	dir := t.TempDir()
+	tf := &Terraform{workingDir: dir}
+
+	for path, valid := range map[string]bool{
+		"generated.tf":                             true,
+		filepath.Join(dir, "generated.tf"):         true,
+		"./sub/../generated.tf":                    true,
+		"sub/generated.tf":                         false,
+		"../generated.tf":                          false,
+		filepath.Join(t.TempDir(), "generated.tf"): false,
+	} {
+		err := tf.checkInWorkingDir(path)
+		if valid && err != nil {
+			t.Errorf("expected %s to be in the working directory, got %s", path, err)
+		}
+		if !valid && err == nil {
+			t.Errorf("expected error for %s, got none", path)
+		}
+	}
+}
+
+func TestCheckImportOnly(t *testing.T) {
+	specs := []ImportSpec{{Address: "aws_instance.web", ID: "i-123"}}
+
+	importing := func(address string, action tfjson.Action) *tfjson.ResourceChange {
+		return &tfjson.ResourceChange{
+			Address: address,
+			Change: &tfjson.Change{
+				Actions:   tfjson.Actions{action},
+				Importing: &tfjson.Importing{ID: "i-123"},
+			},
+		}
+	}
+	change := func(address string, actions ...tfjson.Action) *tfjson.ResourceChange {
+		return &tfjson.ResourceChange{
+			Address: address,
+			Change:  &tfjson.Change{Actions: actions},
+		}
+	}
+
+	for _, c := range []struct {
+		name      string
+		changes   []*tfjson.ResourceChange
+		stateOnly bool
+		wantErr   bool
+	}{
+		{"import only", []*tfjson.ResourceChange{importing("aws_instance.web", tfjson.ActionNoop)}, false, false},
+		{"import with update", []*tfjson.ResourceChange{importing("aws_instance.web", tfjson.ActionUpdate)}, false, false},
+		{"state only import", []*tfjson.ResourceChange{importing("aws_instance.web", tfjson.ActionNoop)}, true, false},
+		{"state only import with update", []*tfjson.ResourceChange{importing("aws_instance.web", tfjson.ActionUpdate)}, true, true},
+		{"unchanged and read", []*tfjson.ResourceChange{
+			importing("aws_instance.web", tfjson.ActionNoop),
+			change("aws_vpc.main", tfjson.ActionNoop),
+			change("data.aws_ami.ubuntu", tfjson.ActionRead),
+		}, false, false},
+		{"unrelated create", []*tfjson.ResourceChange{
+			importing("aws_instance.web", tfjson.ActionNoop),
+			change("aws_instance.other", tfjson.ActionCreate),
+		}, false, true},
+		{"unrelated replace", []*tfjson.ResourceChange{
+			importing("aws_instance.web", tfjson.ActionNoop),
+			change("aws_vpc.main", tfjson.ActionDelete, tfjson.ActionCreate),
+		}, false, true},
+		{"import to other address", []*tfjson.ResourceChange{importing("aws_instance.other", tfjson.ActionUpdate)}, false, true},
+		{"update without import", []*tfjson.ResourceChange{change("aws_instance.web", tfjson.ActionUpdate)}, false, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := checkImportOnly(&tfjson.Plan{ResourceChanges: c.changes}, specs, !c.stateOnly)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("expected error: %t, got %v", c.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"errors"
+	"runtime"
+	"time"
+)
+
+// CancelStage is a stage of the cancellation of a Terraform process, see
+// CancelEscalation.
+type CancelStage int
+
+const (
+	// CancelStageNone means the process was not cancelled, or its
+	// cancellation was not escalated.
+	CancelStageNone CancelStage = iota
+
+	// CancelStageInterrupt means the process ended after being sent SIGINT.
+	CancelStageInterrupt
+
+	// CancelStageTerminate means the process group ended after being sent
+	// SIGTERM.
+	CancelStageTerminate
+
+	// CancelStageKill means the process group was sent SIGKILL.
+	CancelStageKill
+)
+
+func (s CancelStage) String() string {
+	switch s {
+	case CancelStageInterrupt:
+		return "SIGINT"
+	case CancelStageTerminate:
+		return "SIGTERM to process group"
+	case CancelStageKill:
+		return "SIGKILL to process group"
+	}
+	return "none"
+}
+
+// CancelEscalation configures how a Terraform process is stopped when the
+// context of a command is cancelled, see SetCancelEscalation.
+//
+// Terraform is first sent SIGINT to stop gracefully. If it has not exited
+// along with all its provider plugins after InterruptGrace, its whole process
+// group is sent SIGTERM, and after another TermGrace, SIGKILL.
+type CancelEscalation struct {
+	// InterruptGrace is the time to wait after SIGINT, 30s if zero.
+	InterruptGrace time.Duration
+
+	// TermGrace is the time to wait after SIGTERM, 10s if zero.
+	TermGrace time.Duration
+}
+
+func (e *CancelEscalation) interruptGrace() time.Duration {
+	if e.InterruptGrace == 0 {
+		return 30 * time.Second
+	}
+	return e.InterruptGrace
+}
+
+func (e *CancelEscalation) termGrace() time.Duration {
+	if e.TermGrace == 0 {
+		return 10 * time.Second
+	}
+	return e.TermGrace
+}
+
+// SetCancelEscalation sets the CancelEscalation used to stop Terraform
+// processes of this instance when a command's context is cancelled. It takes
+// precedence over SetWaitDelay. Pass nil to restore the default behaviour of
+// sending SIGINT and killing only the Terraform process after the wait delay.
+//
+// Escalation relies on process groups, so it is only supported on Linux.
+func (tf *Terraform) SetCancelEscalation(escalation *CancelEscalation) error {
+	if runtime.GOOS != "linux" {
+		return errors.New("cannot set cancel escalation, process groups are only supported on linux")
+	}
+	tf.cancelEscalation = escalation
+	return nil
+}
+
+// CancellationStage returns the CancelStage which ended a cancelled command,
+// given the error it returned, or CancelStageNone if the command was not
+// cancelled or no CancelEscalation was set.
+func CancellationStage(err error) CancelStage {
+	var e cmdErr
+	if errors.As(err, &e) {
+		return e.stage
+	}
+	return CancelStageNone
+}
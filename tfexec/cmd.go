@@ -33,6 +33,7 @@ const (
 	logProviderEnvVar        = "TF_LOG_PROVIDER"
 	reattachEnvVar           = "TF_REATTACH_PROVIDERS"
 	appendUserAgentEnvVar    = "TF_APPEND_USER_AGENT"
+	dataDirEnvVar            = "TF_DATA_DIR"
 	workspaceEnvVar          = "TF_WORKSPACE"
 	disablePluginTLSEnvVar   = "TF_DISABLE_PLUGIN_TLS"
 	skipProviderVerifyEnvVar = "TF_SKIP_PROVIDER_VERIFY"
@@ -51,6 +52,7 @@ var prohibitedEnvVars = []string{
 	logProviderEnvVar,
 	reattachEnvVar,
 	appendUserAgentEnvVar,
+	dataDirEnvVar,
 	workspaceEnvVar,
 	disablePluginTLSEnvVar,
 	skipProviderVerifyEnvVar,
@@ -177,8 +179,16 @@ func (tf *Terraform) buildEnv(mergeEnv map[string]string) []string {
 	// constant automation override env vars
 	env[automationEnvVar] = "1"
 
-	// force usage of workspace methods for switching
+	// force usage of workspace methods for switching, unless the workspace
+	// is pinned via ForWorkspace
 	delete(env, workspaceEnvVar)
+	if tf.workspace != "" {
+		env[workspaceEnvVar] = tf.workspace
+	}
+
+	if tf.dataDir != "" {
+		env[dataDirEnvVar] = tf.dataDir
+	}
 
 	if tf.disablePluginTLS {
 		env[disablePluginTLSEnvVar] = "1"
@@ -205,11 +215,28 @@ func (tf *Terraform) buildTerraformCmd(ctx context.Context, mergeEnv map[string]
 		cmd.WaitDelay = tf.waitDelay
 	}
 
-	tf.logger.Printf("[INFO] running Terraform command: %s", cmd.String())
+	// with a structured logger, commands are logged once started
+	if tf.slogger == nil {
+		tf.logger.Printf("[INFO] running Terraform command: %s", cmd.String())
+	}
 
 	return cmd
 }
 
+// cmdError returns the error of a command which exited unsuccessfully,
+// including its stderr. It is an *ErrStateLocked if the command failed to
+// acquire the state lock.
+func cmdError(err error, stderr string) error {
+	err = fmt.Errorf("%w\n%s", err, stderr)
+	if info := parseLockInfo(stderr); info != nil {
+		return &ErrStateLocked{
+			LockInfo: info,
+			err:      err,
+		}
+	}
+	return err
+}
+
 func (tf *Terraform) runTerraformCmdJSON(ctx context.Context, cmd *exec.Cmd, v interface{}) error {
 	var outbuf = bytes.Buffer{}
 	cmd.Stdout = mergeWriters(cmd.Stdout, &outbuf)
@@ -9,12 +9,43 @@ package tfexec
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
 	"sync"
+	"time"
 )
 
-func (tf *Terraform) runTerraformCmd(ctx context.Context, cmd *exec.Cmd) error {
+func (tf *Terraform) runTerraformCmd(ctx context.Context, cmd *exec.Cmd) (err error) {
+	// wait for temporary configuration of other instances to be removed, see
+	// lockWorkingDir
+	unlock := tf.rlockWorkingDir(ctx)
+	defer unlock()
+
+	start := time.Now()
+	defer func() {
+		tf.logCmdFinished(ctx, cmd, start, err)
+		tf.reportRunInfo(ctx, cmd, start, err)
+	}()
+
+	err = tf.runBeforeHooks(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	endSpan := tf.startCmdSpan(ctx, cmd)
+	defer func() {
+		endSpan(err)
+	}()
+
+	endLogCapture, err := tf.startLogCapture(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = endLogCapture(err)
+	}()
+
 	var errBuf strings.Builder
 
 	// check for early cancellation
@@ -53,6 +84,7 @@ func (tf *Terraform) runTerraformCmd(ctx context.Context, cmd *exec.Cmd) error {
 	if err != nil {
 		return err
 	}
+	tf.logCmdStarted(ctx, cmd)
 
 	var errStdout, errStderr error
 	var wg sync.WaitGroup
@@ -80,7 +112,7 @@ func (tf *Terraform) runTerraformCmd(ctx context.Context, cmd *exec.Cmd) error {
 		}
 	}
 	if err != nil {
-		return fmt.Errorf("%w\n%s", err, errBuf.String())
+		return cmdError(err, errBuf.String())
 	}
 
 	// Return error if there was an issue reading the std out/err
@@ -93,3 +125,8 @@ func (tf *Terraform) runTerraformCmd(ctx context.Context, cmd *exec.Cmd) error {
 
 	return nil
 }
+
+// maxRSS is only supported on Linux.
+func maxRSS(ps *os.ProcessState) int64 {
+	return 0
+}
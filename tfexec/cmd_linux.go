@@ -6,13 +6,46 @@ package tfexec
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"os"
 	"os/exec"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 )
 
-func (tf *Terraform) runTerraformCmd(ctx context.Context, cmd *exec.Cmd) error {
+func (tf *Terraform) runTerraformCmd(ctx context.Context, cmd *exec.Cmd) (err error) {
+	// wait for temporary configuration of other instances to be removed, see
+	// lockWorkingDir
+	unlock := tf.rlockWorkingDir(ctx)
+	defer unlock()
+
+	start := time.Now()
+	defer func() {
+		tf.logCmdFinished(ctx, cmd, start, err)
+		tf.reportRunInfo(ctx, cmd, start, err)
+	}()
+
+	err = tf.runBeforeHooks(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	endSpan := tf.startCmdSpan(ctx, cmd)
+	defer func() {
+		endSpan(err)
+	}()
+
+	endLogCapture, err := tf.startLogCapture(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = endLogCapture(err)
+	}()
+
 	var errBuf strings.Builder
 
 	cmd.SysProcAttr = &syscall.SysProcAttr{
@@ -22,6 +55,13 @@ func (tf *Terraform) runTerraformCmd(ctx context.Context, cmd *exec.Cmd) error {
 		Setpgid: true,
 	}
 
+	var stage atomic.Int32
+	done := make(chan struct{})
+	defer close(done)
+	if tf.cancelEscalation != nil {
+		tf.escalateCancel(ctx, cmd, tf.cancelEscalation, &stage, done)
+	}
+
 	// check for early cancellation
 	select {
 	case <-ctx.Done():
@@ -53,11 +93,13 @@ func (tf *Terraform) runTerraformCmd(ctx context.Context, cmd *exec.Cmd) error {
 		return cmdErr{
 			err:    err,
 			ctxErr: ctx.Err(),
+			stage:  CancelStage(stage.Load()),
 		}
 	}
 	if err != nil {
 		return err
 	}
+	tf.logCmdStarted(ctx, cmd)
 
 	var errStdout, errStderr error
 	var wg sync.WaitGroup
@@ -79,13 +121,19 @@ func (tf *Terraform) runTerraformCmd(ctx context.Context, cmd *exec.Cmd) error {
 
 	err = cmd.Wait()
 	if ctx.Err() != nil {
+		s := CancelStage(stage.Load())
+		if s != CancelStageNone {
+			tf.logf(ctx, slog.LevelInfo, []slog.Attr{slog.String("stage", s.String())},
+				"cancelled Terraform command ended after %s", s)
+		}
 		return cmdErr{
 			err:    err,
 			ctxErr: ctx.Err(),
+			stage:  s,
 		}
 	}
 	if err != nil {
-		return fmt.Errorf("%w\n%s", err, errBuf.String())
+		return cmdError(err, errBuf.String())
 	}
 
 	// Return error if there was an issue reading the std out/err
@@ -98,3 +146,72 @@ func (tf *Terraform) runTerraformCmd(ctx context.Context, cmd *exec.Cmd) error {
 
 	return nil
 }
+
+// escalateCancel replaces the cancellation of cmd, sending SIGINT to
+// Terraform and then SIGTERM and SIGKILL to its process group until done is
+// closed and no process is left in the group, recording each stage reached.
+func (tf *Terraform) escalateCancel(ctx context.Context, cmd *exec.Cmd, escalation *CancelEscalation, stage *atomic.Int32, done <-chan struct{}) {
+	interruptGrace := escalation.interruptGrace()
+	termGrace := escalation.termGrace()
+
+	cmd.Cancel = func() error {
+		// the process group ID is the PID of Terraform, see Setpgid
+		pgid := cmd.Process.Pid
+
+		stage.Store(int32(CancelStageInterrupt))
+		err := cmd.Process.Signal(os.Interrupt)
+
+		go func() {
+			for _, next := range []struct {
+				grace  time.Duration
+				stage  CancelStage
+				signal syscall.Signal
+			}{
+				{interruptGrace, CancelStageTerminate, syscall.SIGTERM},
+				{termGrace, CancelStageKill, syscall.SIGKILL},
+			} {
+				timer := time.NewTimer(next.grace)
+				select {
+				case <-done:
+					// provider plugins may outlive Terraform in its process
+					// group, so keep escalating until none is left
+					if processGroupGone(pgid) {
+						timer.Stop()
+						return
+					}
+					<-timer.C
+				case <-timer.C:
+				}
+				if processGroupGone(pgid) {
+					return
+				}
+
+				tf.logf(ctx, slog.LevelWarn, []slog.Attr{slog.String("stage", next.stage.String())},
+					"Terraform command did not exit within %s of cancellation, sending %s", next.grace, next.stage)
+				stage.Store(int32(next.stage))
+				_ = syscall.Kill(-pgid, next.signal)
+			}
+		}()
+
+		return err
+	}
+
+	// the process group is killed by the escalation, so Go should not kill
+	// Terraform or stop waiting for its output before it completes
+	cmd.WaitDelay = interruptGrace + termGrace + tf.waitDelay
+}
+
+// processGroupGone reports whether no process is left in the process group
+// with the given ID.
+func processGroupGone(pgid int) bool {
+	return syscall.Kill(-pgid, 0) == syscall.ESRCH
+}
+
+// maxRSS returns the maximum resident set size of a process in bytes.
+func maxRSS(ps *os.ProcessState) int64 {
+	if rusage, ok := ps.SysUsage().(*syscall.Rusage); ok {
+		// Maxrss is in kilobytes on Linux
+		return rusage.Maxrss * 1024
+	}
+	return 0
+}
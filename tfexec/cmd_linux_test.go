@@ -6,8 +6,11 @@ package tfexec
 import (
 	"bytes"
 	"context"
+	"errors"
+	"io"
 	"log"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -37,3 +40,129 @@ func Test_runTerraformCmd_linux(t *testing.T) {
 		t.Fatal("canceling context should not lead to logging an error")
 	}
 }
+
+func Test_runTerraformCmd_cancelEscalation(t *testing.T) {
+	for name, c := range map[string]struct {
+		script   string
+		expected CancelStage
+	}{
+		"interrupt": {
+			script:   "exec sleep 30",
+			expected: CancelStageInterrupt,
+		},
+		"terminate": {
+			// background children of the shell ignore SIGINT
+			script:   "trap '' INT; sleep 30 & wait",
+			expected: CancelStageTerminate,
+		},
+		"kill": {
+			script:   "trap '' INT TERM; sleep 30 & wait",
+			expected: CancelStageKill,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			tf := &Terraform{
+				logger:   log.New(&buf, "", 0),
+				execPath: "sh",
+				cancelEscalation: &CancelEscalation{
+					InterruptGrace: 200 * time.Millisecond,
+					TermGrace:      200 * time.Millisecond,
+				},
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			cmd := tf.buildTerraformCmd(ctx, nil, "-c", c.script)
+			err := tf.runTerraformCmd(ctx, cmd)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Fatalf("expected command to end soon after escalation, took %s", elapsed)
+			}
+
+			stage := CancellationStage(err)
+			if stage != c.expected {
+				t.Fatalf("expected command to end after %s, got %s\n%s", c.expected, stage, buf.String())
+			}
+
+			// no process of the group is left behind, once orphans are reaped
+			for i := 0; syscall.Kill(-cmd.Process.Pid, 0) != syscall.ESRCH; i++ {
+				if i == 50 {
+					t.Fatal("expected process group to be gone")
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+		})
+	}
+}
+
+func Test_runTerraformCmd_cancelEscalationOrphans(t *testing.T) {
+	tf := &Terraform{
+		logger:   log.New(io.Discard, "", 0),
+		execPath: "sh",
+		cancelEscalation: &CancelEscalation{
+			InterruptGrace: 200 * time.Millisecond,
+			TermGrace:      200 * time.Millisecond,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	// the background child ignores SIGINT and outlives the shell, like a
+	// provider plugin outliving Terraform
+	cmd := tf.buildTerraformCmd(ctx, nil, "-c", "sleep 30 </dev/null >/dev/null 2>&1 & exec sleep 30")
+	err := tf.runTerraformCmd(ctx, cmd)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if stage := CancellationStage(err); stage != CancelStageInterrupt {
+		t.Fatalf("expected command to end after %s, got %s", CancelStageInterrupt, stage)
+	}
+
+	for i := 0; syscall.Kill(-cmd.Process.Pid, 0) != syscall.ESRCH; i++ {
+		if i == 50 {
+			t.Fatal("expected process group to be gone")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func Test_runTerraformCmd_runInfo(t *testing.T) {
+	var info *RunInfo
+	tf := &Terraform{
+		logger:   log.New(io.Discard, "", 0),
+		execPath: "sh",
+	}
+	tf.SetRunInfoCallback(func(i *RunInfo) {
+		info = i
+	})
+
+	ctx := context.Background()
+	cmd := tf.buildTerraformCmd(ctx, nil, "-c", "i=0; while [ $i -lt 20000 ]; do i=$((i+1)); done; exit 3", "-var", "password=hunter2")
+	err := tf.runTerraformCmd(ctx, cmd)
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if info == nil {
+		t.Fatal("expected callback to be called")
+	}
+	if info.ExitCode != 3 || info.Err != err {
+		t.Fatalf("unexpected exit code %d and error %v", info.ExitCode, info.Err)
+	}
+	if info.Args[3] != "password=<redacted>" {
+		t.Fatalf("expected args to be redacted, got %q", info.Args)
+	}
+	if info.WallTime <= 0 || info.UserTime+info.SystemTime <= 0 || info.MaxRSS <= 0 {
+		t.Fatalf("expected resource usage, got %#v", info)
+	}
+	if info.TerraformVersion != nil {
+		t.Fatalf("expected unknown version, got %s", info.TerraformVersion)
+	}
+}
@@ -4,11 +4,25 @@
 package tfexec
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
 	"github.com/hashicorp/terraform-exec/internal/version"
 )
 
@@ -102,3 +116,234 @@ func assertCmd(t *testing.T, expectedArgs []string, expectedEnv map[string]strin
 		}
 	}
 }
+
+// shTerraform returns a Terraform instance running sh in place of Terraform,
+// to test how commands are run. The test is skipped if sh is not available.
+func shTerraform(t *testing.T) *Terraform {
+	t.Helper()
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+	return &Terraform{
+		logger:   log.New(io.Discard, "", 0),
+		execPath: "sh",
+	}
+}
+
+func Test_runTerraformCmd_traceparent(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tf := shTerraform(t)
+	tf.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+	var out strings.Builder
+	ctx := context.Background()
+	cmd := tf.buildTerraformCmd(ctx, nil, "-c", `echo "$TRACEPARENT"`)
+	cmd.Stdout = &out
+	err := tf.runTerraformCmd(ctx, cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	sc := spans[0].SpanContext
+	expected := "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01"
+	if strings.TrimSpace(out.String()) != expected {
+		t.Fatalf("expected TRACEPARENT %q, got %q", expected, out.String())
+	}
+	if !slices.Contains(spans[0].Attributes, attribute.Int("terraform.exit_code", 0)) {
+		t.Fatalf("expected exit code attribute, got %v", spans[0].Attributes)
+	}
+}
+
+func Test_runTerraformCmd_hooks(t *testing.T) {
+	var buf bytes.Buffer
+	var calls []string
+	var infos []*RunInfo
+	tf := shTerraform(t)
+	tf.stdout = &buf
+	tf.BeforeRun(
+		func(ctx context.Context, run *CommandRun) error {
+			calls = append(calls, "before 1")
+			run.Env["HOOK_TOKEN"] = "secret"
+			return nil
+		},
+		func(ctx context.Context, run *CommandRun) error {
+			calls = append(calls, "before 2")
+			if run.Args[1] == "exit 4" {
+				return errors.New("vetoed")
+			}
+			run.Args = append(run.Args, "hooked")
+			return nil
+		},
+	)
+	tf.AfterRun(
+		func(ctx context.Context, info *RunInfo) {
+			calls = append(calls, "after 1")
+			infos = append(infos, info)
+		},
+		func(ctx context.Context, info *RunInfo) {
+			calls = append(calls, "after 2")
+		},
+	)
+
+	ctx := context.Background()
+	cmd := tf.buildTerraformCmd(ctx, nil, "-c", `echo "$HOOK_TOKEN $0"`)
+	err := tf.runTerraformCmd(ctx, cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := strings.TrimSpace(buf.String()); out != "secret hooked" {
+		t.Fatalf("expected hooks to change args and env, got %q", out)
+	}
+
+	cmd = tf.buildTerraformCmd(ctx, nil, "-c", "exit 4")
+	err = tf.runTerraformCmd(ctx, cmd)
+	if err == nil || !strings.Contains(err.Error(), "vetoed") {
+		t.Fatalf("expected veto error, got %v", err)
+	}
+	if cmd.Process != nil {
+		t.Fatal("expected vetoed command not to be started")
+	}
+
+	expectedCalls := []string{
+		"before 1", "before 2", "after 2", "after 1",
+		"before 1", "before 2", "after 2", "after 1",
+	}
+	if !slices.Equal(calls, expectedCalls) {
+		t.Fatalf("unexpected hook calls: %q", calls)
+	}
+	if infos[0].ExitCode != 0 || infos[0].Args[2] != "hooked" {
+		t.Fatalf("unexpected info for first run: %#v", infos[0])
+	}
+	if infos[1].ExitCode != -1 || infos[1].Err != err {
+		t.Fatalf("unexpected info for vetoed run: %#v", infos[1])
+	}
+}
+
+func Test_runTerraformCmd_slog(t *testing.T) {
+	var buf bytes.Buffer
+	tf := shTerraform(t)
+	tf.SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	ctx := context.Background()
+	cmd := tf.buildTerraformCmd(ctx, nil, "-c", "exit 3", "-var", "password=hunter2")
+	err := tf.runTerraformCmd(ctx, cmd)
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	var records []map[string]interface{}
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var record map[string]interface{}
+		err := dec.Decode(&record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %v", records)
+	}
+	started, finished := records[0], records[1]
+	if started["msg"] != "Terraform command started" || finished["msg"] != "Terraform command finished" {
+		t.Fatalf("unexpected records: %v", records)
+	}
+	if started["pid"] != float64(cmd.Process.Pid) || finished["pid"] != started["pid"] {
+		t.Fatalf("expected pid %d, got %v", cmd.Process.Pid, records)
+	}
+	if finished["exit_code"] != 3.0 || finished["duration"] == nil || finished["error"] == nil {
+		t.Fatalf("unexpected finished record: %v", finished)
+	}
+	if args := finished["args"].([]interface{}); args[3] != "password=<redacted>" {
+		t.Fatalf("expected args to be redacted, got %q", args)
+	}
+}
+
+func Test_runTerraformCmd_logCapture(t *testing.T) {
+	var captured []*CommandLogs
+	tf := shTerraform(t)
+	tf.logCapture = &LogCapture{
+		Callback: func(logs *CommandLogs) {
+			captured = append(captured, logs)
+		},
+		AttachToError: true,
+	}
+
+	script := `[ "$TF_LOG" = JSON ] || exit 9
+echo "$TF_LOG_PATH" > "$0"
+echo '{"@level":"info","@message":"core","@module":""}' >> "$TF_LOG_PATH"
+echo '{"@level":"debug","@message":"plugin","@module":"provider.terraform-provider-null"}' >> "$TF_LOG_PATH"
+exit $1`
+
+	ctx := context.Background()
+	pathFile := filepath.Join(t.TempDir(), "path")
+	cmd := tf.buildTerraformCmd(ctx, nil, "-c", script, pathFile, "0")
+	err := tf.runTerraformCmd(ctx, cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd = tf.buildTerraformCmd(ctx, nil, "-c", script, pathFile, "1")
+	err = tf.runTerraformCmd(ctx, cmd)
+	var logsErr *ErrWithLogs
+	if !errors.As(err, &logsErr) {
+		t.Fatalf("expected ErrWithLogs, got %#v", err)
+	}
+
+	if len(captured) != 2 || logsErr.Logs != captured[1] {
+		t.Fatalf("expected logs of both commands to be captured, got %#v", captured)
+	}
+	for _, logs := range captured {
+		if len(logs.Core) != 1 || logs.Core[0].Message != "core" || len(logs.Provider) != 1 || logs.Provider[0].Message != "plugin" {
+			t.Fatalf("unexpected logs: %#v", logs)
+		}
+	}
+
+	logPath, err := os.ReadFile(pathFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(strings.TrimSpace(string(logPath))); !os.IsNotExist(err) {
+		t.Fatalf("expected log file to be removed, got %v", err)
+	}
+}
+
+func Test_runTerraformCmd_logCapturePartial(t *testing.T) {
+	var captured *CommandLogs
+	tf := shTerraform(t)
+	tf.logCapture = &LogCapture{
+		Callback: func(logs *CommandLogs) {
+			captured = logs
+		},
+		AttachToError: true,
+	}
+
+	// the second line exceeds the maximum line length
+	script := `echo '{"@level":"info","@message":"core","@module":""}' >> "$TF_LOG_PATH"
+head -c 17000000 /dev/zero | tr '\0' a >> "$TF_LOG_PATH"
+exit 1`
+
+	ctx := context.Background()
+	cmd := tf.buildTerraformCmd(ctx, nil, "-c", script)
+	err := tf.runTerraformCmd(ctx, cmd)
+	var logsErr *ErrWithLogs
+	if !errors.As(err, &logsErr) {
+		t.Fatalf("expected ErrWithLogs, got %#v", err)
+	}
+	if logsErr.ReadErr == nil {
+		t.Fatal("expected read error to be attached")
+	}
+
+	if captured == nil || logsErr.Logs != captured {
+		t.Fatalf("expected partial logs to be passed to the callback, got %#v", captured)
+	}
+	if len(captured.Core) != 1 || captured.Core[0].Message != "core" {
+		t.Fatalf("unexpected logs: %#v", captured)
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/fs"
+	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/releases"
+)
+
+// versionFileName is the name of the file used by version managers such as
+// tfenv to pin the Terraform version of a configuration.
+const versionFileName = ".terraform-version"
+
+type constraintConfig struct {
+	cacheDir        string
+	releasesMirror  string
+	requiredVersion bool
+	searchPath      bool
+	versionFile     bool
+}
+
+var defaultConstraintOptions = constraintConfig{
+	requiredVersion: false,
+	searchPath:      true,
+	versionFile:     false,
+}
+
+// ConstraintOption represents options used in the NewTerraformWithConstraint function.
+type ConstraintOption interface {
+	configureConstraint(*constraintConfig)
+}
+
+// CacheDirOption represents a local directory of cached Terraform binaries.
+type CacheDirOption struct {
+	path string
+}
+
+// CacheDir represents a local directory of cached Terraform binaries, laid out
+// as <path>/<version>/terraform. Binaries installed from a releases mirror are
+// also placed here.
+func CacheDir(path string) *CacheDirOption {
+	return &CacheDirOption{path}
+}
+
+func (opt *CacheDirOption) configureConstraint(conf *constraintConfig) {
+	conf.cacheDir = opt.path
+}
+
+// ReleasesMirrorOption represents a mirror of the HashiCorp releases site.
+type ReleasesMirrorOption struct {
+	url string
+}
+
+// ReleasesMirror represents the base URL of a mirror of the HashiCorp releases
+// site. The directory structure of the mirror must match the releases site,
+// including the index.json files. Installing from a mirror requires a CacheDir
+// to install to.
+func ReleasesMirror(url string) *ReleasesMirrorOption {
+	return &ReleasesMirrorOption{url}
+}
+
+func (opt *ReleasesMirrorOption) configureConstraint(conf *constraintConfig) {
+	conf.releasesMirror = opt.url
+}
+
+// RequiredVersionOption represents whether the required_version constraints
+// of the configuration in the working directory are honored.
+type RequiredVersionOption struct {
+	requiredVersion bool
+}
+
+// RequiredVersion represents whether the required_version constraints of the
+// configuration in the working directory are honored. When enabled, they are
+// added to the constraints. Defaults to false.
+func RequiredVersion(requiredVersion bool) *RequiredVersionOption {
+	return &RequiredVersionOption{requiredVersion}
+}
+
+func (opt *RequiredVersionOption) configureConstraint(conf *constraintConfig) {
+	conf.requiredVersion = opt.requiredVersion
+}
+
+// SearchPathOption represents whether $PATH is searched for a Terraform binary.
+type SearchPathOption struct {
+	searchPath bool
+}
+
+// SearchPath represents whether $PATH is searched for a Terraform binary.
+// Defaults to true.
+func SearchPath(searchPath bool) *SearchPathOption {
+	return &SearchPathOption{searchPath}
+}
+
+func (opt *SearchPathOption) configureConstraint(conf *constraintConfig) {
+	conf.searchPath = opt.searchPath
+}
+
+// VersionFileOption represents whether a .terraform-version file in the
+// working directory is honored.
+type VersionFileOption struct {
+	versionFile bool
+}
+
+// VersionFile represents whether a .terraform-version file in the working
+// directory is honored. When enabled, the pinned version is added to the
+// constraints. Defaults to false.
+func VersionFile(versionFile bool) *VersionFileOption {
+	return &VersionFileOption{versionFile}
+}
+
+func (opt *VersionFileOption) configureConstraint(conf *constraintConfig) {
+	conf.versionFile = opt.versionFile
+}
+
+// NewTerraformWithConstraint returns a Terraform struct for a Terraform
+// executable matching the given version constraint, e.g. ">= 1.6, < 2.0".
+//
+// The executable is looked up in $PATH, then in the local binary cache (see
+// CacheDir) and is finally installed from a releases mirror (see
+// ReleasesMirror) if one is configured. If no suitable executable is found,
+// ErrNoSuitableBinary is returned.
+func NewTerraformWithConstraint(workingDir string, constraint string, opts ...ConstraintOption) (*Terraform, error) {
+	if workingDir == "" {
+		return nil, fmt.Errorf("Terraform cannot be initialised with empty workdir")
+	}
+
+	c := defaultConstraintOptions
+
+	for _, o := range opts {
+		o.configureConstraint(&c)
+	}
+
+	// without a cache directory, installed binaries would be left behind in
+	// a temporary directory
+	if c.releasesMirror != "" && c.cacheDir == "" {
+		return nil, fmt.Errorf("installing from a releases mirror requires a CacheDir")
+	}
+
+	constraints, err := version.NewConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse version constraint %q: %w", constraint, err)
+	}
+
+	if c.versionFile {
+		vfConstraints, err := readVersionFile(workingDir)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, vfConstraints...)
+	}
+
+	if c.requiredVersion {
+		required, err := requiredVersions(workingDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, rv := range required {
+			constraints = append(constraints, rv.constraints...)
+		}
+	}
+
+	execPath, err := findTerraform(context.Background(), constraints, c)
+	if err != nil {
+		return nil, &ErrNoSuitableBinary{
+			err: err,
+		}
+	}
+
+	return NewTerraform(workingDir, execPath)
+}
+
+// readVersionFile returns the constraints for the version pinned in the
+// .terraform-version file of dir, if any.
+func readVersionFile(dir string) (version.Constraints, error) {
+	b, err := os.ReadFile(filepath.Join(dir, versionFileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	raw := strings.TrimSpace(string(b))
+	if raw == "" {
+		return nil, nil
+	}
+
+	v, err := version.NewVersion(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported version %q in %s: %w", raw, versionFileName, err)
+	}
+
+	return version.NewConstraint("= " + v.String())
+}
+
+func findTerraform(ctx context.Context, constraints version.Constraints, c constraintConfig) (string, error) {
+	var errs []error
+
+	if c.searchPath {
+		fv := &fs.Version{
+			Product:     product.Terraform,
+			Constraints: constraints,
+		}
+		execPath, err := fv.Find(ctx)
+		if err == nil {
+			return execPath, nil
+		}
+		errs = append(errs, err)
+	}
+
+	if c.cacheDir != "" {
+		execPath, err := findCachedTerraform(c.cacheDir, constraints)
+		if err == nil {
+			return execPath, nil
+		}
+		errs = append(errs, err)
+	}
+
+	if c.releasesMirror != "" {
+		execPath, err := installTerraform(ctx, constraints, c)
+		if err == nil {
+			return execPath, nil
+		}
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return "", fmt.Errorf("no sources configured to look up %q", constraints)
+	}
+
+	return "", fmt.Errorf("no executable matching %q: %w", constraints, errors.Join(errs...))
+}
+
+// findCachedTerraform returns the newest executable in the cache directory
+// matching the constraints.
+func findCachedTerraform(cacheDir string, constraints version.Constraints) (string, error) {
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		return "", err
+	}
+
+	var versions version.Collection
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		v, err := version.NewVersion(e.Name())
+		if err != nil {
+			continue
+		}
+		if !constraints.Check(v) {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(cacheDir, e.Name(), product.Terraform.BinaryName())); err != nil {
+			continue
+		}
+		versions = append(versions, v)
+	}
+
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no cached executable in %s", cacheDir)
+	}
+
+	sort.Sort(sort.Reverse(versions))
+	v := versions[0]
+
+	return filepath.Abs(filepath.Join(cacheDir, v.Original(), product.Terraform.BinaryName()))
+}
+
+// installTerraform installs the latest version matching the constraints from
+// the releases mirror into the cache directory, so it can be found on
+// subsequent lookups.
+func installTerraform(ctx context.Context, constraints version.Constraints, c constraintConfig) (string, error) {
+	err := os.MkdirAll(c.cacheDir, 0o755)
+	if err != nil {
+		return "", err
+	}
+	installDir, err := os.MkdirTemp(c.cacheDir, ".install-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(installDir)
+
+	lv := &releases.LatestVersion{
+		Product:     product.Terraform,
+		Constraints: constraints,
+		InstallDir:  installDir,
+		ApiBaseURL:  c.releasesMirror,
+	}
+
+	execPath, err := lv.Install(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	v, err := product.Terraform.GetVersion(ctx, execPath)
+	if err != nil {
+		return "", err
+	}
+
+	versionDir := filepath.Join(c.cacheDir, v.String())
+	err = os.MkdirAll(versionDir, 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	cachedPath := filepath.Join(versionDir, product.Terraform.BinaryName())
+	err = os.Rename(execPath, cachedPath)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Abs(cachedPath)
+}
@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hc-install/product"
+)
+
+func TestNewTerraformWithConstraint(t *testing.T) {
+	cacheDir := t.TempDir()
+	for _, v := range []string{"1.5.7", "1.6.6", "1.9.8", "2.0.0"} {
+		dir := filepath.Join(cacheDir, v)
+		err := os.MkdirAll(dir, 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(dir, product.Terraform.BinaryName()), nil, 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("newest matching cached binary", func(t *testing.T) {
+		td := t.TempDir()
+
+		tf, err := NewTerraformWithConstraint(td, ">= 1.6, < 2.0", SearchPath(false), CacheDir(cacheDir))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := filepath.Join(cacheDir, "1.9.8", product.Terraform.BinaryName())
+		if tf.ExecPath() != expected {
+			t.Fatalf("expected exec path %q, got %q", expected, tf.ExecPath())
+		}
+	})
+
+	t.Run("version file", func(t *testing.T) {
+		td := t.TempDir()
+		err := os.WriteFile(filepath.Join(td, ".terraform-version"), []byte("1.6.6\n"), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tf, err := NewTerraformWithConstraint(td, ">= 1.6, < 2.0", SearchPath(false), CacheDir(cacheDir), VersionFile(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := filepath.Join(cacheDir, "1.6.6", product.Terraform.BinaryName())
+		if tf.ExecPath() != expected {
+			t.Fatalf("expected exec path %q, got %q", expected, tf.ExecPath())
+		}
+	})
+
+	t.Run("required version", func(t *testing.T) {
+		td := t.TempDir()
+		err := os.WriteFile(filepath.Join(td, "versions.tf"), []byte(`terraform { required_version = "~> 1.6.0" }`), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tf, err := NewTerraformWithConstraint(td, ">= 1.5", SearchPath(false), CacheDir(cacheDir), RequiredVersion(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := filepath.Join(cacheDir, "1.6.6", product.Terraform.BinaryName())
+		if tf.ExecPath() != expected {
+			t.Fatalf("expected exec path %q, got %q", expected, tf.ExecPath())
+		}
+	})
+
+	t.Run("no matching binary", func(t *testing.T) {
+		td := t.TempDir()
+
+		_, err := NewTerraformWithConstraint(td, "~> 1.7.0", SearchPath(false), CacheDir(cacheDir))
+		var e *ErrNoSuitableBinary
+		if !errors.As(err, &e) {
+			t.Fatalf("expected ErrNoSuitableBinary, got %T %s", err, err)
+		}
+	})
+
+	t.Run("releases mirror without cache dir", func(t *testing.T) {
+		td := t.TempDir()
+
+		_, err := NewTerraformWithConstraint(td, ">= 1.6", SearchPath(false), ReleasesMirror("https://releases.example.com"))
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+
+	t.Run("invalid constraint", func(t *testing.T) {
+		td := t.TempDir()
+
+		_, err := NewTerraformWithConstraint(td, "not a constraint", SearchPath(false), CacheDir(cacheDir))
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+}
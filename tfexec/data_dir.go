@@ -0,0 +1,210 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultDataDir is the data directory used by Terraform when TF_DATA_DIR is not set.
+const defaultDataDir = ".terraform"
+
+// DataDir returns the path to the data directory used by Terraform, i.e. the
+// path set via SetDataDir, the inherited TF_DATA_DIR environment variable or
+// .terraform, resolved against the working directory.
+func (tf *Terraform) DataDir() string {
+	dataDir := tf.dataDir
+	if dataDir == "" && tf.env == nil {
+		dataDir = os.Getenv(dataDirEnvVar)
+	}
+	if dataDir == "" {
+		dataDir = defaultDataDir
+	}
+
+	if filepath.IsAbs(dataDir) {
+		return dataDir
+	}
+	return filepath.Join(tf.workingDir, dataDir)
+}
+
+// CloneDataDir copies the initialized data directory of tf, including the
+// backend configuration, installed modules and providers, to path and returns
+// a new Terraform instance which uses the copy as its data directory.
+//
+// This allows several variants of the same root module (e.g. with different
+// backends or variables) to be initialized side by side. Symbolic links, such
+// as providers linked from a plugin cache, are preserved as links. The module
+// manifest is updated to load installed modules from the copy, unless the
+// modules directory itself is a link. path must not exist yet.
+func (tf *Terraform) CloneDataDir(path string) (*Terraform, error) {
+	src := tf.DataDir()
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read data directory %s, has Terraform been initialized?: %w", src, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("data directory %s is not a directory", src)
+	}
+
+	dst := path
+	if !filepath.IsAbs(dst) {
+		dst = filepath.Join(tf.workingDir, dst)
+	}
+
+	if _, err := os.Lstat(dst); err == nil {
+		return nil, fmt.Errorf("unable to clone data directory to %s: %w", dst, fs.ErrExist)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	err = copyDir(src, dst)
+	if err != nil {
+		return nil, fmt.Errorf("unable to clone data directory %s to %s: %w", src, dst, err)
+	}
+
+	err = rewriteModuleManifest(tf.workingDir, src, dst)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update module manifest of %s: %w", dst, err)
+	}
+
+	ctf := tf.clone()
+	ctf.dataDir = path
+	return ctf, nil
+}
+
+// rewriteModuleManifest updates the module manifest in the data directory dst,
+// copied from src, so that modules installed in src are loaded from dst.
+// Directories in the manifest are relative to workingDir, unless absolute.
+func rewriteModuleManifest(workingDir, src, dst string) error {
+	modulesDir := filepath.Join(dst, "modules")
+	info, err := os.Lstat(modulesDir)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && !info.IsDir()) {
+		// no modules installed, or shared via a link
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	manifestPath := filepath.Join(modulesDir, "modules.json")
+	b, err := os.ReadFile(manifestPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// unknown fields are preserved as is
+	var manifest map[string]json.RawMessage
+	err = json.Unmarshal(b, &manifest)
+	if err != nil {
+		return err
+	}
+	var records []map[string]json.RawMessage
+	err = json.Unmarshal(manifest["Modules"], &records)
+	if err != nil {
+		return err
+	}
+
+	srcModulesDir := filepath.Join(src, "modules")
+	for _, r := range records {
+		var dir string
+		err := json.Unmarshal(r["Dir"], &dir)
+		if err != nil {
+			return err
+		}
+
+		absDir := filepath.FromSlash(dir)
+		if !filepath.IsAbs(absDir) {
+			absDir = filepath.Join(workingDir, absDir)
+		}
+		rel, err := filepath.Rel(srcModulesDir, absDir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			// e.g. local modules outside of the data directory
+			continue
+		}
+
+		newDir := filepath.Join(modulesDir, rel)
+		if !filepath.IsAbs(filepath.FromSlash(dir)) {
+			newDir, err = filepath.Rel(workingDir, newDir)
+			if err != nil {
+				return err
+			}
+		}
+
+		r["Dir"], err = json.Marshal(filepath.ToSlash(newDir))
+		if err != nil {
+			return err
+		}
+	}
+
+	manifest["Modules"], err = json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	b, err = json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(manifestPath, b, 0o644)
+}
+
+// copyDir recursively copies the directory src to dst, preserving file modes
+// and symbolic links.
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		default:
+			return copyFile(path, target, info.Mode().Perm())
+		}
+	})
+}
+
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	return errors.Join(err, out.Close())
+}
@@ -0,0 +1,227 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-exec/tfexec/internal/testutil"
+)
+
+func TestSetDataDir(t *testing.T) {
+	td := t.TempDir()
+
+	tf, err := NewTerraform(td, tfVersion(t, testutil.Latest_v1))
+	if err != nil {
+		t.Fatalf("unexpected NewTerraform error: %s", err)
+	}
+
+	// Required so all testing environment variables are not copied.
+	err = tf.SetEnv(map[string]string{
+		"CLEARENV": "1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected SetEnv error: %s", err)
+	}
+
+	err = tf.SetDataDir("variant-a")
+	if err != nil {
+		t.Fatalf("unexpected SetDataDir error: %s", err)
+	}
+
+	initCmd, err := tf.initCmd(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected command error: %s", err)
+	}
+
+	assertCmd(t, []string{
+		"init",
+		"-no-color",
+		"-input=false",
+		"-backend=true",
+		"-get=true",
+		"-upgrade=false",
+	}, map[string]string{
+		"CLEARENV":    "1",
+		"TF_DATA_DIR": "variant-a",
+	}, initCmd)
+
+	if expected := filepath.Join(td, "variant-a"); tf.DataDir() != expected {
+		t.Fatalf("expected data dir %q, got %q", expected, tf.DataDir())
+	}
+}
+
+func TestCloneDataDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks and file modes are not portable to windows")
+	}
+
+	td := t.TempDir()
+
+	tf, err := NewTerraform(td, tfVersion(t, testutil.Latest_v1))
+	if err != nil {
+		t.Fatalf("unexpected NewTerraform error: %s", err)
+	}
+
+	// Required so all testing environment variables are not copied.
+	err = tf.SetEnv(map[string]string{
+		"CLEARENV": "1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected SetEnv error: %s", err)
+	}
+
+	t.Run("not initialized", func(t *testing.T) {
+		_, err := tf.CloneDataDir("variant-a")
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+
+	// fake an initialized data directory
+	providerDir := filepath.Join(td, ".terraform", "providers", "registry.terraform.io", "hashicorp", "null", "3.2.2", "linux_amd64")
+	err = os.MkdirAll(providerDir, 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(providerDir, "terraform-provider-null"), []byte("provider"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(td, ".terraform", "environment"), []byte("staging"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Symlink(filepath.Join(td, "modules-cache"), filepath.Join(td, ".terraform", "modules"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("clone", func(t *testing.T) {
+		ctf, err := tf.CloneDataDir("variant-a")
+		if err != nil {
+			t.Fatalf("unexpected CloneDataDir error: %s", err)
+		}
+
+		if expected := filepath.Join(td, "variant-a"); ctf.DataDir() != expected {
+			t.Fatalf("expected data dir %q, got %q", expected, ctf.DataDir())
+		}
+		if expected := filepath.Join(td, ".terraform"); tf.DataDir() != expected {
+			t.Fatalf("expected original data dir %q, got %q", expected, tf.DataDir())
+		}
+
+		b, err := os.ReadFile(filepath.Join(td, "variant-a", "environment"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != "staging" {
+			t.Fatalf("expected environment %q, got %q", "staging", string(b))
+		}
+
+		info, err := os.Stat(filepath.Join(td, "variant-a", "providers", "registry.terraform.io", "hashicorp", "null", "3.2.2", "linux_amd64", "terraform-provider-null"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm()&0o100 == 0 {
+			t.Fatalf("expected provider to be executable, got mode %s", info.Mode())
+		}
+
+		link, err := os.Readlink(filepath.Join(td, "variant-a", "modules"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := filepath.Join(td, "modules-cache"); link != expected {
+			t.Fatalf("expected symlink to %q, got %q", expected, link)
+		}
+	})
+
+	t.Run("destination exists", func(t *testing.T) {
+		_, err := tf.CloneDataDir("variant-a")
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+}
+
+func TestCloneDataDir_moduleManifest(t *testing.T) {
+	td := t.TempDir()
+
+	tf, err := NewTerraform(td, tfVersion(t, testutil.Latest_v1))
+	if err != nil {
+		t.Fatalf("unexpected NewTerraform error: %s", err)
+	}
+
+	// Required so all testing environment variables are not copied.
+	err = tf.SetEnv(map[string]string{
+		"CLEARENV": "1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected SetEnv error: %s", err)
+	}
+
+	// fake a data directory with a local module, an installed module and a
+	// local module nested in the installed one
+	err = os.MkdirAll(filepath.Join(td, ".terraform", "modules", "remote", "sub"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := `{"Modules":[
+		{"Key":"","Source":"","Dir":"."},
+		{"Key":"local","Source":"./local","Dir":"local"},
+		{"Key":"remote","Source":"registry.terraform.io/vancluever/module/null","Version":"1.0.1","Dir":".terraform/modules/remote"},
+		{"Key":"remote.sub","Source":"./sub","Dir":".terraform/modules/remote/sub"}
+	]}`
+	err = os.WriteFile(filepath.Join(td, ".terraform", "modules", "modules.json"), []byte(manifest), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = tf.CloneDataDir("variant-a")
+	if err != nil {
+		t.Fatalf("unexpected CloneDataDir error: %s", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(td, "variant-a", "modules", "modules.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actual struct {
+		Modules []map[string]string
+	}
+	err = json.Unmarshal(b, &actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"":           ".",
+		"local":      "local",
+		"remote":     "variant-a/modules/remote",
+		"remote.sub": "variant-a/modules/remote/sub",
+	}
+	if len(actual.Modules) != len(expected) {
+		t.Fatalf("expected %d modules, got %v", len(expected), actual.Modules)
+	}
+	for _, m := range actual.Modules {
+		if m["Dir"] != expected[m["Key"]] {
+			t.Errorf("expected dir %q for module %q, got %q", expected[m["Key"]], m["Key"], m["Dir"])
+		}
+	}
+	if actual.Modules[2]["Version"] != "1.0.1" {
+		t.Errorf("expected other fields to be preserved, got %v", actual.Modules[2])
+	}
+
+	b, err = os.ReadFile(filepath.Join(td, ".terraform", "modules", "modules.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != manifest {
+		t.Fatal("expected original manifest not to be modified")
+	}
+}
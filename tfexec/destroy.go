@@ -14,11 +14,14 @@ import (
 type destroyConfig struct {
 	backup string
 	dir    string
+	guard  *ChangeGuard
 	lock   bool
 
 	// LockTimeout must be a string with time unit, e.g. '10s'
 	lockTimeout  string
 	parallelism  int
+	policies     []PolicyEvaluator
+	policyReport *PolicyReport
 	reattachInfo ReattachInfo
 	refresh      bool
 	state        string
@@ -86,12 +89,31 @@ func (opt *VarOption) configureDestroy(conf *destroyConfig) {
 	conf.vars = append(conf.vars, opt.assignment)
 }
 
+func (opt *GuardOption) configureDestroy(conf *destroyConfig) {
+	conf.guard = opt.guard
+}
+
+func (opt *PolicyOption) configureDestroy(conf *destroyConfig) {
+	conf.policies = append(conf.policies, opt.evaluators...)
+}
+
+func (opt *PolicyReportOutOption) configureDestroy(conf *destroyConfig) {
+	conf.policyReport = opt.report
+}
+
 func (opt *ReattachOption) configureDestroy(conf *destroyConfig) {
 	conf.reattachInfo = opt.info
 }
 
 // Destroy represents the terraform destroy subcommand.
+//
+// If a ChangeGuard or policies are set, a destroy plan is created and checked
+// against them and then applied instead, see ChangeGuard and PolicyEvaluator.
 func (tf *Terraform) Destroy(ctx context.Context, opts ...DestroyOption) error {
+	if applyOpts, ok := tf.destroyCheckedApplyOptions(opts); ok {
+		return tf.Apply(ctx, applyOpts...)
+	}
+
 	cmd, err := tf.destroyCmd(ctx, opts...)
 	if err != nil {
 		return err
@@ -110,6 +132,10 @@ func (tf *Terraform) DestroyJSON(ctx context.Context, w io.Writer, opts ...Destr
 		return fmt.Errorf("terraform destroy -json was added in 0.15.3: %w", err)
 	}
 
+	if applyOpts, ok := tf.destroyCheckedApplyOptions(opts); ok {
+		return tf.ApplyJSON(ctx, w, applyOpts...)
+	}
+
 	tf.SetStdout(w)
 
 	cmd, err := tf.destroyJSONCmd(ctx, opts...)
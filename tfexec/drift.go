@@ -0,0 +1,199 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-exec/tfexec/analysis"
+)
+
+type driftConfig struct {
+	dir          string
+	lock         bool
+	lockTimeout  string
+	parallelism  int
+	reattachInfo ReattachInfo
+	state        string
+	targets      []string
+	vars         []string
+	varFiles     []string
+}
+
+var defaultDriftOptions = driftConfig{
+	lock:        true,
+	lockTimeout: "0s",
+	parallelism: 10,
+}
+
+// DriftOption represents options used in the DetectDrift method.
+type DriftOption interface {
+	configureDrift(*driftConfig)
+}
+
+func (opt *DirOption) configureDrift(conf *driftConfig) {
+	conf.dir = opt.path
+}
+
+func (opt *LockOption) configureDrift(conf *driftConfig) {
+	conf.lock = opt.lock
+}
+
+func (opt *LockTimeoutOption) configureDrift(conf *driftConfig) {
+	conf.lockTimeout = opt.timeout
+}
+
+func (opt *ParallelismOption) configureDrift(conf *driftConfig) {
+	conf.parallelism = opt.parallelism
+}
+
+func (opt *ReattachOption) configureDrift(conf *driftConfig) {
+	conf.reattachInfo = opt.info
+}
+
+func (opt *StateOption) configureDrift(conf *driftConfig) {
+	conf.state = opt.path
+}
+
+func (opt *TargetOption) configureDrift(conf *driftConfig) {
+	conf.targets = append(conf.targets, opt.target)
+}
+
+func (opt *VarOption) configureDrift(conf *driftConfig) {
+	conf.vars = append(conf.vars, opt.assignment)
+}
+
+func (opt *VarFileOption) configureDrift(conf *driftConfig) {
+	conf.varFiles = append(conf.varFiles, opt.path)
+}
+
+// DriftReport is the result of DetectDrift.
+type DriftReport struct {
+	// NoDrift is true if no resource has changed outside of Terraform.
+	NoDrift bool
+
+	// Resources lists the resources which have changed outside of Terraform,
+	// in the order reported by Terraform.
+	Resources []*DriftedResource
+
+	// Plan is the refresh-only plan the report is based on.
+	Plan *tfjson.Plan
+}
+
+// DriftedResource is a resource which has changed outside of Terraform.
+type DriftedResource struct {
+	Address       string
+	ModuleAddress string
+	Type          string
+
+	// Action is analysis.ActionUpdate if the resource was modified, or
+	// analysis.ActionDelete if it no longer exists.
+	Action analysis.Action
+
+	// Attributes lists the attribute values which differ between the state
+	// and the remote object, empty if the object was deleted. Sensitive
+	// values are masked.
+	Attributes []analysis.AttributeChange
+
+	// Change is the raw drift as found in the plan.
+	Change *tfjson.ResourceChange
+}
+
+// DetectDrift runs a refresh-only plan into a temporary plan file and reports
+// the resources which have changed outside of Terraform since the state was
+// last written. The plan is never applied, so the state is not modified.
+func (tf *Terraform) DetectDrift(ctx context.Context, opts ...DriftOption) (*DriftReport, error) {
+	c := defaultDriftOptions
+	for _, o := range opts {
+		o.configureDrift(&c)
+	}
+
+	dir, err := os.MkdirTemp("", "tfexec-drift")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	planPath := filepath.Join(dir, "tfplan")
+
+	planOpts := []PlanOption{
+		Out(planPath),
+		RefreshOnly(true),
+		Lock(c.lock),
+		LockTimeout(c.lockTimeout),
+		Parallelism(c.parallelism),
+	}
+	if c.dir != "" {
+		planOpts = append(planOpts, Dir(c.dir))
+	}
+	if c.reattachInfo != nil {
+		planOpts = append(planOpts, Reattach(c.reattachInfo))
+	}
+	if c.state != "" {
+		planOpts = append(planOpts, State(c.state))
+	}
+	for _, target := range c.targets {
+		planOpts = append(planOpts, Target(target))
+	}
+	for _, v := range c.vars {
+		planOpts = append(planOpts, Var(v))
+	}
+	for _, vf := range c.varFiles {
+		planOpts = append(planOpts, VarFile(vf))
+	}
+
+	_, err = tf.Plan(ctx, planOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var showOpts []ShowOption
+	if c.reattachInfo != nil {
+		showOpts = append(showOpts, Reattach(c.reattachInfo))
+	}
+
+	plan, err := tf.ShowPlanFile(ctx, planPath, showOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDriftReport(plan), nil
+}
+
+func newDriftReport(plan *tfjson.Plan) *DriftReport {
+	report := &DriftReport{
+		Plan: plan,
+	}
+
+	for _, rc := range plan.ResourceDrift {
+		if rc == nil || rc.Change == nil {
+			continue
+		}
+
+		action := analysis.ActionOf(rc.Change.Actions)
+		if action == analysis.ActionNoOp {
+			continue
+		}
+
+		r := &DriftedResource{
+			Address:       rc.Address,
+			ModuleAddress: rc.ModuleAddress,
+			Type:          rc.Type,
+			Action:        action,
+			Change:        rc,
+		}
+		if action != analysis.ActionDelete {
+			r.Attributes = analysis.AttributeChanges(rc.Change)
+		}
+		report.Resources = append(report.Resources, r)
+	}
+
+	report.NoDrift = len(report.Resources) == 0
+
+	return report
+}
@@ -0,0 +1,80 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-exec/tfexec/analysis"
+)
+
+func TestNewDriftReport(t *testing.T) {
+	t.Run("no drift", func(t *testing.T) {
+		report := newDriftReport(&tfjson.Plan{})
+		if !report.NoDrift {
+			t.Fatal("expected no drift")
+		}
+	})
+
+	t.Run("drift", func(t *testing.T) {
+		plan := &tfjson.Plan{
+			ResourceDrift: []*tfjson.ResourceChange{
+				{
+					Address: "aws_instance.web",
+					Type:    "aws_instance",
+					Change: &tfjson.Change{
+						Actions: tfjson.Actions{tfjson.ActionUpdate},
+						Before:  map[string]interface{}{"instance_type": "t3.micro"},
+						After:   map[string]interface{}{"instance_type": "t3.large"},
+					},
+				},
+				{
+					Address:       "module.logs.aws_s3_bucket.main",
+					ModuleAddress: "module.logs",
+					Type:          "aws_s3_bucket",
+					Change: &tfjson.Change{
+						Actions: tfjson.Actions{tfjson.ActionDelete},
+						Before:  map[string]interface{}{"bucket": "logs"},
+					},
+				},
+			},
+		}
+
+		report := newDriftReport(plan)
+		if report.NoDrift {
+			t.Fatal("expected drift")
+		}
+
+		actual := make([]DriftedResource, 0, len(report.Resources))
+		for _, r := range report.Resources {
+			r := *r
+			r.Change = nil
+			actual = append(actual, r)
+		}
+
+		expected := []DriftedResource{
+			{
+				Address: "aws_instance.web",
+				Type:    "aws_instance",
+				Action:  analysis.ActionUpdate,
+				Attributes: []analysis.AttributeChange{
+					{Path: "instance_type", Before: "t3.micro", After: "t3.large"},
+				},
+			},
+			{
+				Address:       "module.logs.aws_s3_bucket.main",
+				ModuleAddress: "module.logs",
+				Type:          "aws_s3_bucket",
+				Action:        analysis.ActionDelete,
+			},
+		}
+
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Fatalf("unexpected drift: %s", diff)
+		}
+	})
+}
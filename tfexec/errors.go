@@ -6,6 +6,8 @@ package tfexec
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // this file contains non-parsed exported errors
@@ -34,6 +36,95 @@ func (e *ErrVersionMismatch) Error() string {
 	return fmt.Sprintf("unexpected version %s (min: %s, max: %s)", e.Actual, e.MinInclusive, e.MaxExclusive)
 }
 
+// ErrRequiredVersionMismatch is returned when the Terraform version does not satisfy a
+// required_version constraint declared in the configuration.
+type ErrRequiredVersionMismatch struct {
+	Filename   string
+	Constraint string
+	Actual     string
+}
+
+func (e *ErrRequiredVersionMismatch) Error() string {
+	return fmt.Sprintf("version %s does not satisfy required_version %q in %s", e.Actual, e.Constraint, e.Filename)
+}
+
+// ErrPlanFileChanged is returned when a saved plan file was modified between
+// being planned and applied.
+type ErrPlanFileChanged struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+func (e *ErrPlanFileChanged) Error() string {
+	return fmt.Sprintf("plan file %s changed before apply (expected sha256 %s, got %s)", e.Path, e.Expected, e.Actual)
+}
+
+// ErrDestructiveChange is returned when a plan violates a ChangeGuard, listing the protected
+// resources which would be deleted or replaced and all resources which would be deleted or
+// replaced.
+type ErrDestructiveChange struct {
+	Protected    []string
+	Deleted      []string
+	MaxDeletions int
+}
+
+func (e *ErrDestructiveChange) Error() string {
+	if len(e.Protected) > 0 {
+		return fmt.Sprintf("refusing to apply plan deleting or replacing protected resources: %s", strings.Join(e.Protected, ", "))
+	}
+	return fmt.Sprintf("refusing to apply plan deleting or replacing %d resources (max: %d): %s", len(e.Deleted), e.MaxDeletions, strings.Join(e.Deleted, ", "))
+}
+
+// ErrPolicyFailed is returned when a plan fails one or more policies, see
+// PolicyEvaluator.
+type ErrPolicyFailed struct {
+	Report *PolicyReport
+}
+
+func (e *ErrPolicyFailed) Error() string {
+	failures := e.Report.Failures()
+	msgs := make([]string, 0, len(failures))
+	for _, f := range failures {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", f.Policy, f.Message))
+	}
+	return fmt.Sprintf("refusing to apply plan failing %d policy checks: %s", len(failures), strings.Join(msgs, "; "))
+}
+
+// ErrGraphCycle is returned when a dependency graph contains cycles, each
+// listed as the IDs of the nodes forming it.
+type ErrGraphCycle struct {
+	Cycles [][]string
+}
+
+func (e *ErrGraphCycle) Error() string {
+	cycles := make([]string, 0, len(e.Cycles))
+	for _, c := range e.Cycles {
+		cycles = append(cycles, strings.Join(c, ", "))
+	}
+	return fmt.Sprintf("graph contains %d cycles: [%s]", len(e.Cycles), strings.Join(cycles, "], ["))
+}
+
+// ErrFormatSnippets is returned by Formatter.FormatBatch when some snippets
+// are not valid HCL, mapping the index of each invalid snippet to its error.
+type ErrFormatSnippets struct {
+	Errors map[int]error
+}
+
+func (e *ErrFormatSnippets) Error() string {
+	indexes := make([]int, 0, len(e.Errors))
+	for i := range e.Errors {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	msgs := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		msgs = append(msgs, e.Errors[i].Error())
+	}
+	return fmt.Sprintf("unable to format %d snippets: %s", len(indexes), strings.Join(msgs, "; "))
+}
+
 // ErrManualEnvVar is returned when an env var that should be set programatically via an option or method
 // is set via the manual environment passing functions.
 type ErrManualEnvVar struct {
@@ -52,6 +143,9 @@ func (err *ErrManualEnvVar) Error() string {
 type cmdErr struct {
 	err    error
 	ctxErr error
+
+	// stage is the stage of a CancelEscalation which ended the command
+	stage CancelStage
 }
 
 func (e cmdErr) Is(target error) bool {
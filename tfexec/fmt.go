@@ -69,33 +69,74 @@ func (tf *Terraform) Format(ctx context.Context, unformatted io.Reader, formatte
 }
 
 // FormatWrite attempts to format and modify all config files in the working or selected (via DirOption) directory.
-func (tf *Terraform) FormatWrite(ctx context.Context, opts ...FormatOption) error {
-	for _, o := range opts {
-		switch o := o.(type) {
-		case *DirOption:
-			if o.path == "-" {
-				return fmt.Errorf("a path of \"-\" is not supported for this method, please use FormatString")
-			}
-		}
+// It returns the list of files which were rewritten, empty if all files were already formatted.
+//
+// Besides .tf and .tfvars files, Terraform formats .tftest.hcl files since 1.6 and .tfmock.hcl
+// files since 1.7. A single file of any of these kinds can be selected via DirOption.
+func (tf *Terraform) FormatWrite(ctx context.Context, opts ...FormatOption) ([]string, error) {
+	err := checkFormatPath(opts)
+	if err != nil {
+		return nil, err
 	}
 
-	cmd, err := tf.formatCmd(ctx, []string{"-write=true", "-list=false", "-diff=false"}, opts...)
+	cmd, err := tf.formatCmd(ctx, []string{"-write=true", "-list=true", "-diff=false"}, opts...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return tf.runTerraformCmd(ctx, cmd)
+	var outBuf strings.Builder
+	cmd.Stdout = mergeWriters(cmd.Stdout, &outBuf)
+
+	err = tf.runTerraformCmd(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFormatList(outBuf.String()), nil
+}
+
+// FormatFileDiff is the change formatting would make to a single file.
+type FormatFileDiff struct {
+	// File is the path of the file, relative to the working directory unless an absolute
+	// path was selected via DirOption.
+	File string
+
+	// Diff is the unified diff between the file and its formatted content.
+	Diff string
+}
+
+// FormatDiff returns the changes formatting would make to the config files in the working or
+// selected (via DirOption) directory, one per unformatted file, without modifying any file.
+// It covers the same kinds of files as FormatWrite.
+//
+// Terraform relies on the diff command to produce the diffs, so it must be available on the PATH.
+func (tf *Terraform) FormatDiff(ctx context.Context, opts ...FormatOption) ([]FormatFileDiff, error) {
+	err := checkFormatPath(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd, err := tf.formatCmd(ctx, []string{"-write=false", "-list=false", "-diff=true"}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var outBuf strings.Builder
+	cmd.Stdout = mergeWriters(cmd.Stdout, &outBuf)
+
+	err = tf.runTerraformCmd(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFormatDiff(outBuf.String()), nil
 }
 
 // FormatCheck returns true if the config files in the working or selected (via DirOption) directory are already formatted.
 func (tf *Terraform) FormatCheck(ctx context.Context, opts ...FormatOption) (bool, []string, error) {
-	for _, o := range opts {
-		switch o := o.(type) {
-		case *DirOption:
-			if o.path == "-" {
-				return false, nil, fmt.Errorf("a path of \"-\" is not supported for this method, please use FormatString")
-			}
-		}
+	err := checkFormatPath(opts)
+	if err != nil {
+		return false, nil, err
 	}
 
 	cmd, err := tf.formatCmd(ctx, []string{"-write=false", "-list=true", "-diff=false", "-check=true"}, opts...)
@@ -112,20 +153,63 @@ func (tf *Terraform) FormatCheck(ctx context.Context, opts ...FormatOption) (boo
 	}
 	if cmd.ProcessState.ExitCode() == 3 {
 		// unformatted, parse the file list
+		return false, parseFormatList(outBuf.String()), nil
+	}
+	return false, nil, err
+}
 
-		files := []string{}
-		lines := strings.Split(strings.Replace(outBuf.String(), "\r\n", "\n", -1), "\n")
-		for _, l := range lines {
-			l = strings.TrimSpace(l)
-			if l == "" {
-				continue
+func checkFormatPath(opts []FormatOption) error {
+	for _, o := range opts {
+		switch o := o.(type) {
+		case *DirOption:
+			if o.path == "-" {
+				return fmt.Errorf("a path of \"-\" is not supported for this method, please use FormatString")
 			}
-			files = append(files, l)
 		}
+	}
+	return nil
+}
 
-		return false, files, nil
+// parseFormatList parses the file list printed by fmt with -list=true.
+func parseFormatList(out string) []string {
+	files := []string{}
+	lines := strings.Split(strings.Replace(out, "\r\n", "\n", -1), "\n")
+	for _, l := range lines {
+		l = strings.TrimSpace(l)
+		if l == "" {
+			continue
+		}
+		files = append(files, l)
 	}
-	return false, nil, err
+	return files
+}
+
+// parseFormatDiff splits the output of fmt with -diff=true into the diff of each file.
+// Terraform labels both sides of each diff with the file path, prefixed with old/ and new/.
+func parseFormatDiff(out string) []FormatFileDiff {
+	diffs := []FormatFileDiff{}
+	lines := strings.Split(strings.Replace(out, "\r\n", "\n", -1), "\n")
+
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		diffs = append(diffs, FormatFileDiff{
+			File: strings.TrimPrefix(lines[start], "--- old/"),
+			Diff: strings.TrimRight(strings.Join(lines[start:end], "\n"), "\n") + "\n",
+		})
+	}
+
+	for i, l := range lines {
+		if strings.HasPrefix(l, "--- old/") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ new/") {
+			flush(i)
+			start = i
+		}
+	}
+	flush(len(lines))
+
+	return diffs
 }
 
 func (tf *Terraform) formatCmd(ctx context.Context, args []string, opts ...FormatOption) (*exec.Cmd, error) {
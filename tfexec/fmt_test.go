@@ -8,6 +8,8 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/hashicorp/terraform-exec/tfexec/internal/testutil"
 )
 
@@ -57,3 +59,54 @@ func TestFormatCmd(t *testing.T) {
 		}, nil, fmtCmd)
 	})
 }
+
+func TestParseFormatDiff(t *testing.T) {
+	out := `--- old/main.tf
++++ new/main.tf
+@@ -1,3 +1,3 @@
+ resource "foo" "bar" {
+-	baz   = 1
++  baz = 1
+ }
+
+--- old/tests/main.tftest.hcl
++++ new/tests/main.tftest.hcl
+@@ -1,2 +1,2 @@
+-run    "test" {
++run "test" {
+ }
+
+`
+
+	expected := []FormatFileDiff{
+		{
+			File: "main.tf",
+			Diff: `--- old/main.tf
++++ new/main.tf
+@@ -1,3 +1,3 @@
+ resource "foo" "bar" {
+-	baz   = 1
++  baz = 1
+ }
+`,
+		},
+		{
+			File: "tests/main.tftest.hcl",
+			Diff: `--- old/tests/main.tftest.hcl
++++ new/tests/main.tftest.hcl
+@@ -1,2 +1,2 @@
+-run    "test" {
++run "test" {
+ }
+`,
+		},
+	}
+
+	if diff := cmp.Diff(expected, parseFormatDiff(out)); diff != "" {
+		t.Fatalf("unexpected diffs: %s", diff)
+	}
+
+	if diffs := parseFormatDiff(""); len(diffs) != 0 {
+		t.Fatalf("expected no diffs, got %#v", diffs)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// maxFormatBatchSize limits the number of files written to a single
+// temporary directory, and thus the work done by a single fmt run.
+const maxFormatBatchSize = 500
+
+// Formatter formats configuration snippets in batches, writing each batch to
+// a temporary directory formatted by a single `terraform fmt` run, instead of
+// starting a process per snippet as FormatString does.
+//
+// A Formatter is safe for concurrent use. The number of fmt processes it runs
+// at any time is bounded by the number of workers it was created with.
+type Formatter struct {
+	tf      *Terraform
+	workers chan struct{}
+}
+
+// NewFormatter returns a Formatter running at most workers concurrent fmt
+// processes using tf. At least one worker is used.
+func NewFormatter(tf *Terraform, workers int) *Formatter {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Formatter{
+		tf:      tf,
+		workers: make(chan struct{}, workers),
+	}
+}
+
+// FormatString formats a single snippet.
+func (f *Formatter) FormatString(ctx context.Context, content string) (string, error) {
+	formatted, err := f.FormatBatch(ctx, []string{content})
+	if err != nil {
+		if e, ok := err.(*ErrFormatSnippets); ok {
+			return "", e.Errors[0]
+		}
+		return "", err
+	}
+	return formatted[0], nil
+}
+
+// FormatBatch formats the given snippets, returning the formatted snippets
+// in the same order. Large batches are split across the workers.
+//
+// Snippets which are not valid HCL are not formatted. If there are any, the
+// other snippets are still formatted and returned, along with an
+// *ErrFormatSnippets describing the invalid ones, which are returned
+// unchanged.
+func (f *Formatter) FormatBatch(ctx context.Context, snippets []string) ([]string, error) {
+	formatted := make([]string, len(snippets))
+	copy(formatted, snippets)
+
+	snippetErrs := map[int]error{}
+	var valid []int
+	for i, s := range snippets {
+		_, diags := hclsyntax.ParseConfig([]byte(s), fmt.Sprintf("snippet %d", i), hcl.InitialPos)
+		if diags.HasErrors() {
+			snippetErrs[i] = diags
+			continue
+		}
+		valid = append(valid, i)
+	}
+
+	// spread the snippets evenly across the workers, within the batch size limit
+	batchSize := (len(valid) + cap(f.workers) - 1) / cap(f.workers)
+	if batchSize > maxFormatBatchSize {
+		batchSize = maxFormatBatchSize
+	}
+
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var batchErr error
+	setErr := func(err error) {
+		errOnce.Do(func() { batchErr = err })
+	}
+
+dispatch:
+	for start := 0; start < len(valid); start += batchSize {
+		batch := valid[start:min(start+batchSize, len(valid))]
+
+		select {
+		case f.workers <- struct{}{}:
+		case <-ctx.Done():
+			setErr(ctx.Err())
+			break dispatch
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-f.workers }()
+
+			err := f.formatBatch(ctx, snippets, formatted, batch)
+			if err != nil {
+				setErr(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if batchErr != nil {
+		return nil, batchErr
+	}
+	if len(snippetErrs) > 0 {
+		return formatted, &ErrFormatSnippets{Errors: snippetErrs}
+	}
+	return formatted, nil
+}
+
+// formatBatch formats the snippets at the given indexes with a single fmt
+// run, storing the results at the same indexes of formatted.
+func (f *Formatter) formatBatch(ctx context.Context, snippets, formatted []string, indexes []int) error {
+	dir, err := os.MkdirTemp("", "tfexec-fmt")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	path := func(i int) string {
+		return filepath.Join(dir, fmt.Sprintf("snippet-%d.tf", i))
+	}
+
+	for _, i := range indexes {
+		err := os.WriteFile(path(i), []byte(snippets[i]), 0600)
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd, err := f.tf.formatCmd(ctx, []string{"-write=true", "-list=false", "-diff=false"}, Dir(dir))
+	if err != nil {
+		return err
+	}
+
+	err = f.tf.runTerraformCmd(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	for _, i := range indexes {
+		b, err := os.ReadFile(path(i))
+		if err != nil {
+			return err
+		}
+		formatted[i] = string(b)
+	}
+
+	return nil
+}
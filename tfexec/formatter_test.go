@@ -0,0 +1,43 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-exec/tfexec/internal/testutil"
+)
+
+func TestFormatterInvalidSnippets(t *testing.T) {
+	tf, err := NewTerraform(t.TempDir(), tfVersion(t, testutil.Latest_v1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := NewFormatter(tf, 2)
+
+	// no valid snippets, so fmt is never run
+	snippets := []string{`resource "foo" "bar" {`, "baz = "}
+	formatted, err := f.FormatBatch(context.Background(), snippets)
+
+	var e *ErrFormatSnippets
+	if !errors.As(err, &e) {
+		t.Fatalf("expected ErrFormatSnippets, got %T %s", err, err)
+	}
+	if len(e.Errors) != 2 || e.Errors[0] == nil || e.Errors[1] == nil {
+		t.Fatalf("unexpected snippet errors: %#v", e.Errors)
+	}
+	for i := range snippets {
+		if formatted[i] != snippets[i] {
+			t.Fatalf("expected invalid snippet %d to be unchanged, got %q", i, formatted[i])
+		}
+	}
+
+	_, err = f.FormatString(context.Background(), "baz = ")
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
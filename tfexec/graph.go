@@ -10,10 +10,30 @@ import (
 	"strings"
 )
 
+// GraphTypeName is the type of graph drawn by terraform graph.
+type GraphTypeName string
+
+const (
+	GraphTypePlan        GraphTypeName = "plan"
+	GraphTypePlanDestroy GraphTypeName = "plan-destroy"
+	GraphTypeApply       GraphTypeName = "apply"
+
+	// GraphTypePlanRefreshOnly requires Terraform 0.15.4 or later.
+	GraphTypePlanRefreshOnly GraphTypeName = "plan-refresh-only"
+
+	// GraphTypeValidate is only supported prior to Terraform 1.7.
+	GraphTypeValidate GraphTypeName = "validate"
+
+	// GraphTypeInput and GraphTypeRefresh are only supported prior to
+	// Terraform 0.15.
+	GraphTypeInput   GraphTypeName = "input"
+	GraphTypeRefresh GraphTypeName = "refresh"
+)
+
 type graphConfig struct {
 	plan       string
 	drawCycles bool
-	graphType  string
+	graphType  GraphTypeName
 }
 
 var defaultGraphOptions = graphConfig{}
@@ -81,7 +101,29 @@ func (tf *Terraform) graphCmd(ctx context.Context, opts ...GraphOption) (*exec.C
 		if err != nil {
 			return nil, fmt.Errorf("-graph-type was first introduced in Terraform 0.8.0: %w", err)
 		}
-		args = append(args, "-type="+c.graphType)
+
+		switch c.graphType {
+		case GraphTypePlan, GraphTypePlanDestroy, GraphTypeApply:
+		case GraphTypePlanRefreshOnly:
+			err := tf.compatible(ctx, tf0_15_4, nil)
+			if err != nil {
+				return nil, fmt.Errorf("graph type %q was introduced in Terraform 0.15.4: %w", c.graphType, err)
+			}
+		case GraphTypeValidate:
+			err := tf.compatible(ctx, nil, tf1_7_0)
+			if err != nil {
+				return nil, fmt.Errorf("graph type %q was removed in Terraform 1.7.0: %w", c.graphType, err)
+			}
+		case GraphTypeInput, GraphTypeRefresh:
+			err := tf.compatible(ctx, tf0_8_0, tf0_15_0)
+			if err != nil {
+				return nil, fmt.Errorf("graph type %q was removed in Terraform 0.15.0: %w", c.graphType, err)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported graph type %q", c.graphType)
+		}
+
+		args = append(args, "-type="+string(c.graphType))
 	}
 
 	return tf.buildTerraformCmd(ctx, nil, args...), nil
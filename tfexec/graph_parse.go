@@ -0,0 +1,571 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GraphNodeKind is the kind of a node in a dependency graph.
+type GraphNodeKind string
+
+const (
+	GraphNodeResource GraphNodeKind = "resource"
+	GraphNodeProvider GraphNodeKind = "provider"
+
+	// GraphNodeOther is any other node, such as a module variable, output or
+	// the internal nodes drawn by Terraform versions prior to 1.7.
+	GraphNodeOther GraphNodeKind = "other"
+)
+
+// GraphNode is a node in a dependency graph.
+type GraphNode struct {
+	// ID is the node ID used in the DOT output. Since Terraform 1.7, it is
+	// the resource address.
+	ID    string
+	Label string
+
+	// Address is the address of the object the node represents, without the
+	// "[root] " prefix and suffixes such as " (expand)" used by Terraform
+	// versions prior to 1.7.
+	Address string
+
+	// Module is the address of the module the node belongs to, empty for the
+	// root module.
+	Module string
+
+	Kind GraphNodeKind
+
+	// Provider is the provider a resource depends on, if the graph includes
+	// provider nodes.
+	Provider string
+}
+
+// GraphEdge is a dependency of the From node on the To node.
+type GraphEdge struct {
+	From string
+	To   string
+}
+
+// Graph is a dependency graph as returned by GraphParsed.
+type Graph struct {
+	// Nodes maps node IDs to nodes.
+	Nodes map[string]*GraphNode
+
+	// Edges lists all dependencies, in the order they were declared.
+	Edges []GraphEdge
+
+	dependencies map[string][]string
+	dependents   map[string][]string
+}
+
+// GraphParsed runs `terraform graph` and parses the DOT output into a Graph.
+func (tf *Terraform) GraphParsed(ctx context.Context, opts ...GraphOption) (*Graph, error) {
+	out, err := tf.Graph(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseGraph(out)
+}
+
+// ParseGraph parses the DOT output of `terraform graph`.
+func ParseGraph(dot string) (*Graph, error) {
+	p := &dotParser{tokens: tokenizeDOT(dot)}
+	g := newGraph()
+
+	err := p.parse(g)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse graph: %w", err)
+	}
+
+	for _, e := range g.Edges {
+		from, to := g.Nodes[e.From], g.Nodes[e.To]
+		if from.Kind == GraphNodeResource && to.Kind == GraphNodeProvider {
+			from.Provider = to.Address
+		}
+	}
+
+	return g, nil
+}
+
+func newGraph() *Graph {
+	return &Graph{
+		Nodes:        map[string]*GraphNode{},
+		dependencies: map[string][]string{},
+		dependents:   map[string][]string{},
+	}
+}
+
+func (g *Graph) addNode(id, label string) *GraphNode {
+	n, ok := g.Nodes[id]
+	if !ok {
+		n = newGraphNode(id)
+		g.Nodes[id] = n
+	}
+	if label != "" {
+		n.Label = label
+	}
+	return n
+}
+
+func (g *Graph) addEdge(from, to string) {
+	g.addNode(from, "")
+	g.addNode(to, "")
+	g.Edges = append(g.Edges, GraphEdge{From: from, To: to})
+	g.dependencies[from] = append(g.dependencies[from], to)
+	g.dependents[to] = append(g.dependents[to], from)
+}
+
+func newGraphNode(id string) *GraphNode {
+	addr := strings.TrimPrefix(id, "[root] ")
+	for _, suffix := range []string{" (expand)", " (close)"} {
+		addr = strings.TrimSuffix(addr, suffix)
+	}
+
+	n := &GraphNode{
+		ID:      id,
+		Label:   addr,
+		Address: addr,
+		Kind:    GraphNodeOther,
+	}
+
+	rest := addr
+	var module []string
+	for strings.HasPrefix(rest, "module.") {
+		step, tail, ok := splitModuleStep(rest)
+		if !ok {
+			break
+		}
+		module = append(module, step)
+		rest = tail
+	}
+	n.Module = strings.Join(module, ".")
+
+	switch {
+	case strings.HasSuffix(id, " (close)"):
+	case strings.HasPrefix(rest, "provider[") || strings.HasPrefix(rest, "provider."):
+		n.Kind = GraphNodeProvider
+	case strings.HasPrefix(rest, "data.") && isResourceAddress(strings.TrimPrefix(rest, "data.")):
+		n.Kind = GraphNodeResource
+	case isResourceAddress(rest):
+		n.Kind = GraphNodeResource
+	}
+
+	return n
+}
+
+// splitModuleStep splits the first module step, such as module.a or
+// module.a["key"], off an address. ok is false if nothing follows the step.
+func splitModuleStep(addr string) (step, rest string, ok bool) {
+	i := len("module.")
+	for i < len(addr) && addr[i] != '.' && addr[i] != '[' {
+		i++
+	}
+	if i < len(addr) && addr[i] == '[' {
+		// skip instance key, which may contain dots within quotes
+		quoted := false
+		for i++; i < len(addr); i++ {
+			if quoted && addr[i] == '\\' {
+				i++
+				continue
+			}
+			if addr[i] == '"' {
+				quoted = !quoted
+			}
+			if addr[i] == ']' && !quoted {
+				i++
+				break
+			}
+		}
+	}
+	if i >= len(addr) || addr[i] != '.' {
+		return "", "", false
+	}
+	return addr[:i], addr[i+1:], true
+}
+
+// isResourceAddress reports whether addr looks like a managed resource
+// address relative to its module, e.g. aws_instance.web or aws_instance.web[0].
+func isResourceAddress(addr string) bool {
+	typ, name, ok := strings.Cut(addr, ".")
+	if !ok || typ == "" || name == "" || strings.Contains(name, " ") {
+		return false
+	}
+	switch typ {
+	case "var", "local", "output", "module", "meta", "root", "provider", "check", "ephemeral", "action", "list":
+		return false
+	}
+	return strings.Contains(typ, "_")
+}
+
+// Node returns the node with the given ID or address, or nil if there is
+// none.
+func (g *Graph) Node(idOrAddress string) *GraphNode {
+	if n, ok := g.Nodes[idOrAddress]; ok {
+		return n
+	}
+	for _, id := range g.nodeIDs() {
+		if g.Nodes[id].Address == idOrAddress {
+			return g.Nodes[id]
+		}
+	}
+	return nil
+}
+
+// Dependencies returns the IDs of the nodes the given node depends on
+// directly, sorted.
+func (g *Graph) Dependencies(id string) []string {
+	return sortedUnique(g.dependencies[id])
+}
+
+// Dependents returns the IDs of the nodes which depend on the given node
+// directly, sorted.
+func (g *Graph) Dependents(id string) []string {
+	return sortedUnique(g.dependents[id])
+}
+
+// AllDependencies returns the IDs of the nodes the given node depends on,
+// directly or transitively, sorted.
+func (g *Graph) AllDependencies(id string) []string {
+	return g.reachable(id, g.dependencies)
+}
+
+// AllDependents returns the IDs of the nodes which depend on the given node,
+// directly or transitively, sorted. This is the set of nodes which may be
+// affected by a change to the given node.
+func (g *Graph) AllDependents(id string) []string {
+	return g.reachable(id, g.dependents)
+}
+
+func (g *Graph) reachable(id string, adjacent map[string][]string) []string {
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	var result []string
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, a := range adjacent[next] {
+			if seen[a] {
+				continue
+			}
+			seen[a] = true
+			result = append(result, a)
+			queue = append(queue, a)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Subgraph returns the graph induced by the given node IDs, i.e. those nodes
+// and all edges between them. Unknown IDs are ignored.
+func (g *Graph) Subgraph(ids ...string) *Graph {
+	include := map[string]bool{}
+	for _, id := range ids {
+		if _, ok := g.Nodes[id]; ok {
+			include[id] = true
+		}
+	}
+
+	sub := newGraph()
+	for id := range include {
+		n := *g.Nodes[id]
+		sub.Nodes[id] = &n
+	}
+	for _, e := range g.Edges {
+		if include[e.From] && include[e.To] {
+			sub.addEdge(e.From, e.To)
+		}
+	}
+
+	return sub
+}
+
+// TopologicalOrder returns all node IDs ordered so that every node comes
+// after the nodes it depends on. Nodes without an ordering constraint are
+// sorted by ID. ErrGraphCycle is returned if the graph contains cycles.
+func (g *Graph) TopologicalOrder() ([]string, error) {
+	if cycles := g.Cycles(); len(cycles) > 0 {
+		return nil, &ErrGraphCycle{Cycles: cycles}
+	}
+
+	remaining := map[string]int{}
+	for id := range g.Nodes {
+		remaining[id] = len(g.Dependencies(id))
+	}
+
+	var ready []string
+	for id, n := range remaining {
+		if n == 0 {
+			ready = append(ready, id)
+		}
+	}
+	sort.Strings(ready)
+
+	order := make([]string, 0, len(g.Nodes))
+	for len(ready) > 0 {
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+
+		var unblocked []string
+		for _, d := range g.Dependents(id) {
+			remaining[d]--
+			if remaining[d] == 0 {
+				unblocked = append(unblocked, d)
+			}
+		}
+		ready = append(ready, unblocked...)
+		sort.Strings(ready)
+	}
+
+	return order, nil
+}
+
+// Cycles returns the cycles in the graph, each as the sorted IDs of the nodes
+// forming a strongly connected component. Cycles are sorted by their first ID.
+func (g *Graph) Cycles() [][]string {
+	index := 0
+	indices := map[string]int{}
+	lowlink := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var cycles [][]string
+
+	var strongConnect func(id string)
+	strongConnect = func(id string) {
+		indices[id] = index
+		lowlink[id] = index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		selfLoop := false
+		for _, dep := range g.Dependencies(id) {
+			if dep == id {
+				selfLoop = true
+			}
+			if _, ok := indices[dep]; !ok {
+				strongConnect(dep)
+				lowlink[id] = min(lowlink[id], lowlink[dep])
+			} else if onStack[dep] {
+				lowlink[id] = min(lowlink[id], indices[dep])
+			}
+		}
+
+		if lowlink[id] != indices[id] {
+			return
+		}
+
+		var component []string
+		for {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[n] = false
+			component = append(component, n)
+			if n == id {
+				break
+			}
+		}
+		if len(component) > 1 || selfLoop {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, id := range g.nodeIDs() {
+		if _, ok := indices[id]; !ok {
+			strongConnect(id)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0] < cycles[j][0]
+	})
+
+	return cycles
+}
+
+func (g *Graph) nodeIDs() []string {
+	ids := make([]string, 0, len(g.Nodes))
+	for id := range g.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func sortedUnique(ids []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// dotParser parses the subset of the DOT language emitted by Terraform.
+type dotParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *dotParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *dotParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *dotParser) parse(g *Graph) error {
+	if t := p.next(); t != "digraph" {
+		return fmt.Errorf("expected digraph, got %q", t)
+	}
+	if p.peek() != "{" {
+		p.next() // graph ID
+	}
+	if t := p.next(); t != "{" {
+		return fmt.Errorf("expected {, got %q", t)
+	}
+
+	return p.parseStatements(g)
+}
+
+// parseStatements parses statements until the closing brace of the current
+// graph or subgraph.
+func (p *dotParser) parseStatements(g *Graph) error {
+	for {
+		t := p.next()
+		switch t {
+		case "":
+			return fmt.Errorf("unexpected end of graph")
+		case "}":
+			return nil
+		case ";", "\n":
+			continue
+		case "subgraph":
+			if p.peek() != "{" {
+				p.next() // subgraph ID
+			}
+			if t := p.next(); t != "{" {
+				return fmt.Errorf("expected {, got %q", t)
+			}
+			err := p.parseStatements(g)
+			if err != nil {
+				return err
+			}
+			continue
+		case "node", "edge", "graph":
+			if p.peek() == "[" {
+				p.parseAttributes()
+				continue
+			}
+		}
+
+		switch p.peek() {
+		case "=":
+			// graph attribute
+			p.next()
+			p.next()
+		case "->":
+			ids := []string{unquoteDOT(t)}
+			for p.peek() == "->" {
+				p.next()
+				ids = append(ids, unquoteDOT(p.next()))
+			}
+			if p.peek() == "[" {
+				p.parseAttributes()
+			}
+			for i := 0; i < len(ids)-1; i++ {
+				g.addEdge(ids[i], ids[i+1])
+			}
+		default:
+			var label string
+			if p.peek() == "[" {
+				label = unquoteDOT(p.parseAttributes()["label"])
+			}
+			g.addNode(unquoteDOT(t), label)
+		}
+	}
+}
+
+func (p *dotParser) parseAttributes() map[string]string {
+	attrs := map[string]string{}
+	p.next() // [
+	for {
+		t := p.next()
+		switch t {
+		case "", "]":
+			return attrs
+		case ",", ";", "\n":
+			continue
+		}
+		if p.peek() == "=" {
+			p.next()
+			attrs[t] = p.next()
+		}
+	}
+}
+
+// tokenizeDOT splits DOT source into quoted strings (kept with their quotes),
+// identifiers, punctuation and newlines, which separate statements.
+func tokenizeDOT(src string) []string {
+	var tokens []string
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			tokens = append(tokens, "\n")
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			tokens = append(tokens, src[i:min(j+1, len(src))])
+			i = j + 1
+		case c == '-' && i+1 < len(src) && src[i+1] == '>':
+			tokens = append(tokens, "->")
+			i += 2
+		case strings.IndexByte("{}[]=;,", c) >= 0:
+			tokens = append(tokens, string(c))
+			i++
+		default:
+			j := i
+			for j < len(src) && strings.IndexByte(" \t\r\n\"{}[]=;,", src[j]) < 0 && !(src[j] == '-' && j+1 < len(src) && src[j+1] == '>') {
+				j++
+			}
+			tokens = append(tokens, src[i:j])
+			i = j
+		}
+	}
+	return tokens
+}
+
+func unquoteDOT(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+	s = s[1 : len(s)-1]
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(s)
+}
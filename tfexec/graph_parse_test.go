@@ -0,0 +1,164 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseGraph(t *testing.T) {
+	t.Run("legacy", func(t *testing.T) {
+		g, err := ParseGraph(`digraph {
+	compound = "true"
+	newrank = "true"
+	subgraph "root" {
+		"[root] null_resource.foo (expand)" [label = "null_resource.foo", shape = "box"]
+		"[root] provider[\"registry.terraform.io/hashicorp/null\"]" [label = "provider[\"registry.terraform.io/hashicorp/null\"]", shape = "diamond"]
+		"[root] null_resource.foo (expand)" -> "[root] provider[\"registry.terraform.io/hashicorp/null\"]"
+		"[root] provider[\"registry.terraform.io/hashicorp/null\"] (close)" -> "[root] null_resource.foo (expand)"
+		"[root] root" -> "[root] provider[\"registry.terraform.io/hashicorp/null\"] (close)"
+	}
+}
+
+`)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(g.Nodes) != 4 || len(g.Edges) != 3 {
+			t.Fatalf("expected 4 nodes and 3 edges, got %d and %d", len(g.Nodes), len(g.Edges))
+		}
+
+		expected := &GraphNode{
+			ID:       "[root] null_resource.foo (expand)",
+			Label:    "null_resource.foo",
+			Address:  "null_resource.foo",
+			Kind:     GraphNodeResource,
+			Provider: `provider["registry.terraform.io/hashicorp/null"]`,
+		}
+		if diff := cmp.Diff(expected, g.Node("null_resource.foo")); diff != "" {
+			t.Fatalf("unexpected node: %s", diff)
+		}
+
+		order, err := g.TopologicalOrder()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedOrder := []string{
+			`[root] provider["registry.terraform.io/hashicorp/null"]`,
+			"[root] null_resource.foo (expand)",
+			`[root] provider["registry.terraform.io/hashicorp/null"] (close)`,
+			"[root] root",
+		}
+		if diff := cmp.Diff(expectedOrder, order); diff != "" {
+			t.Fatalf("unexpected order: %s", diff)
+		}
+	})
+
+	t.Run("modules", func(t *testing.T) {
+		g, err := ParseGraph(`digraph G {
+  rankdir = "RL";
+  node [shape = rect, fontname = "sans-serif"];
+  "aws_vpc.main" [label="aws_vpc.main"];
+  "data.aws_ami.ubuntu" [label="data.aws_ami.ubuntu"];
+  subgraph "cluster_module.app" {
+    label = "module.app"
+    fontname = "sans-serif"
+    "module.app.aws_instance.web" [label="aws_instance.web"];
+    "module.app.aws_eip.web" [label="aws_eip.web"];
+  }
+  subgraph "cluster_module.dns[\"a.example\"]" {
+    label = "module.dns[\"a.example\"]"
+    "module.dns[\"a.example\"].aws_route53_record.web" [label="aws_route53_record.web"];
+  }
+  "module.app.aws_instance.web" -> "aws_vpc.main";
+  "module.app.aws_instance.web" -> "data.aws_ami.ubuntu";
+  "module.app.aws_eip.web" -> "module.app.aws_instance.web";
+  "module.dns[\"a.example\"].aws_route53_record.web" -> "module.app.aws_eip.web";
+}
+`)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n := g.Nodes[`module.dns["a.example"].aws_route53_record.web`]; n.Module != `module.dns["a.example"]` || n.Kind != GraphNodeResource || n.Label != "aws_route53_record.web" {
+			t.Fatalf("unexpected node: %#v", n)
+		}
+		if n := g.Nodes["data.aws_ami.ubuntu"]; n.Module != "" || n.Kind != GraphNodeResource {
+			t.Fatalf("unexpected node: %#v", n)
+		}
+
+		expectedDependents := []string{
+			"module.app.aws_eip.web",
+			"module.app.aws_instance.web",
+			`module.dns["a.example"].aws_route53_record.web`,
+		}
+		if diff := cmp.Diff(expectedDependents, g.AllDependents("aws_vpc.main")); diff != "" {
+			t.Fatalf("unexpected dependents: %s", diff)
+		}
+		if diff := cmp.Diff([]string{"module.app.aws_eip.web"}, g.Dependents("module.app.aws_instance.web")); diff != "" {
+			t.Fatalf("unexpected dependents: %s", diff)
+		}
+		if diff := cmp.Diff([]string{"aws_vpc.main", "data.aws_ami.ubuntu", "module.app.aws_instance.web"}, g.AllDependencies("module.app.aws_eip.web")); diff != "" {
+			t.Fatalf("unexpected dependencies: %s", diff)
+		}
+
+		order, err := g.TopologicalOrder()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedOrder := []string{
+			"aws_vpc.main",
+			"data.aws_ami.ubuntu",
+			"module.app.aws_instance.web",
+			"module.app.aws_eip.web",
+			`module.dns["a.example"].aws_route53_record.web`,
+		}
+		if diff := cmp.Diff(expectedOrder, order); diff != "" {
+			t.Fatalf("unexpected order: %s", diff)
+		}
+
+		sub := g.Subgraph("module.app.aws_instance.web", "module.app.aws_eip.web", "unknown")
+		if len(sub.Nodes) != 2 {
+			t.Fatalf("expected 2 nodes, got %d", len(sub.Nodes))
+		}
+		expectedEdges := []GraphEdge{{From: "module.app.aws_eip.web", To: "module.app.aws_instance.web"}}
+		if diff := cmp.Diff(expectedEdges, sub.Edges); diff != "" {
+			t.Fatalf("unexpected edges: %s", diff)
+		}
+	})
+
+	t.Run("cycles", func(t *testing.T) {
+		g, err := ParseGraph(`digraph G {
+  "a_b.a" -> "a_b.b" -> "a_b.c" -> "a_b.a";
+  "a_b.d" -> "a_b.d";
+  "a_b.e" -> "a_b.a";
+}
+`)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := [][]string{{"a_b.a", "a_b.b", "a_b.c"}, {"a_b.d"}}
+		if diff := cmp.Diff(expected, g.Cycles()); diff != "" {
+			t.Fatalf("unexpected cycles: %s", diff)
+		}
+
+		_, err = g.TopologicalOrder()
+		var e *ErrGraphCycle
+		if !errors.As(err, &e) {
+			t.Fatalf("expected ErrGraphCycle, got %T %s", err, err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseGraph(`digraph {`)
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+}
@@ -38,13 +38,25 @@ func TestGraphCmd_v013(t *testing.T) {
 		graphCmd, _ := tf.graphCmd(context.Background(),
 			GraphPlan("teststate"),
 			DrawCycles(true),
-			GraphType("output"))
+			GraphType(GraphTypePlan))
 
 		assertCmd(t, []string{
 			"graph",
 			"teststate",
 			"-draw-cycles",
-			"-type=output",
+			"-type=plan",
+		}, nil, graphCmd)
+	})
+
+	t.Run("refresh graph type", func(t *testing.T) {
+		graphCmd, err := tf.graphCmd(context.Background(), GraphType(GraphTypeRefresh))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertCmd(t, []string{
+			"graph",
+			"-type=refresh",
 		}, nil, graphCmd)
 	})
 }
@@ -72,13 +84,22 @@ func TestGraphCmd_v1(t *testing.T) {
 		graphCmd, _ := tf.graphCmd(context.Background(),
 			GraphPlan("teststate"),
 			DrawCycles(true),
-			GraphType("output"))
+			GraphType(GraphTypePlan))
 
 		assertCmd(t, []string{
 			"graph",
 			"-plan=teststate",
 			"-draw-cycles",
-			"-type=output",
+			"-type=plan",
 		}, nil, graphCmd)
 	})
+
+	t.Run("removed graph types", func(t *testing.T) {
+		for _, graphType := range []GraphTypeName{GraphTypeInput, GraphTypeRefresh, GraphTypeValidate} {
+			_, err := tf.graphCmd(context.Background(), GraphType(graphType))
+			if err == nil {
+				t.Fatalf("expected error for graph type %q, got none", graphType)
+			}
+		}
+	})
 }
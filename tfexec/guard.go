@@ -0,0 +1,113 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-exec/tfexec/analysis"
+)
+
+// ChangeGuard describes destructive changes which must not be applied. It can
+// be attached to a Terraform instance via SetGuard or passed to Apply and
+// Destroy via the Guard option.
+//
+// Guarded applies always go through a saved plan: when Apply is not given a
+// plan file, or when Destroy is used, a plan is created first, inspected and
+// then applied. ErrDestructiveChange is returned without applying anything if
+// the plan violates the guard.
+type ChangeGuard struct {
+	// ProtectedAddresses are resource address patterns which must not be
+	// deleted or replaced. The wildcard * matches any sequence of characters,
+	// e.g. "module.db.*" or "aws_db_instance.*".
+	ProtectedAddresses []string
+
+	// ProtectedTypes are resource types which must not be deleted or replaced.
+	ProtectedTypes []string
+
+	// MaxDeletions, if greater than zero, is the maximum number of resources
+	// which may be deleted or replaced.
+	MaxDeletions int
+}
+
+// Check returns ErrDestructiveChange if the plan deletes or replaces
+// protected resources, or more resources than allowed.
+func (g *ChangeGuard) Check(plan *tfjson.Plan) error {
+	if plan == nil {
+		return nil
+	}
+
+	var protected, deleted []string
+	for _, c := range plan.ResourceChanges {
+		if c.Change == nil {
+			continue
+		}
+		switch analysis.ActionOf(c.Change.Actions) {
+		case analysis.ActionDelete, analysis.ActionReplace:
+			deleted = append(deleted, c.Address)
+			if g.protects(c) {
+				protected = append(protected, c.Address)
+			}
+		}
+	}
+
+	if len(protected) > 0 || (g.MaxDeletions > 0 && len(deleted) > g.MaxDeletions) {
+		return &ErrDestructiveChange{
+			Protected:    protected,
+			Deleted:      deleted,
+			MaxDeletions: g.MaxDeletions,
+		}
+	}
+
+	return nil
+}
+
+func (g *ChangeGuard) protects(c *tfjson.ResourceChange) bool {
+	for _, t := range g.ProtectedTypes {
+		if c.Type == t {
+			return true
+		}
+	}
+	for _, p := range g.ProtectedAddresses {
+		if matchAddress(p, c.Address) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAddress matches a resource address against a pattern in which *
+// matches any sequence of characters. Unlike path.Match, brackets in
+// addresses such as aws_instance.web["a"] need no escaping.
+func matchAddress(pattern, addr string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == addr
+	}
+
+	if !strings.HasPrefix(addr, parts[0]) {
+		return false
+	}
+	addr = addr[len(parts[0]):]
+
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(addr, part)
+		if i < 0 {
+			return false
+		}
+		addr = addr[i+len(part):]
+	}
+
+	return strings.HasSuffix(addr, last)
+}
+
+// SetGuard attaches a ChangeGuard to every Apply and Destroy call of this
+// instance. A Guard option passed to the call takes precedence. Pass nil to
+// remove the guard.
+func (tf *Terraform) SetGuard(guard *ChangeGuard) {
+	tf.guard = guard
+}
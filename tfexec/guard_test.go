@@ -0,0 +1,124 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+func TestChangeGuardCheck(t *testing.T) {
+	plan := &tfjson.Plan{
+		FormatVersion: "1.2",
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "aws_db_instance.main",
+				Type:    "aws_db_instance",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}},
+			},
+			{
+				Address: `module.app.aws_instance.web["a"]`,
+				Type:    "aws_instance",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete}},
+			},
+			{
+				Address: "aws_s3_bucket.logs",
+				Type:    "aws_s3_bucket",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionUpdate}},
+			},
+		},
+	}
+
+	for _, c := range []struct {
+		name      string
+		guard     *ChangeGuard
+		protected []string
+		deleted   []string
+	}{
+		{
+			"empty guard",
+			&ChangeGuard{},
+			nil,
+			nil,
+		},
+		{
+			"protected type",
+			&ChangeGuard{ProtectedTypes: []string{"aws_db_instance"}},
+			[]string{"aws_db_instance.main"},
+			[]string{"aws_db_instance.main", `module.app.aws_instance.web["a"]`},
+		},
+		{
+			"protected address pattern",
+			&ChangeGuard{ProtectedAddresses: []string{"module.app.*"}},
+			[]string{`module.app.aws_instance.web["a"]`},
+			[]string{"aws_db_instance.main", `module.app.aws_instance.web["a"]`},
+		},
+		{
+			"unaffected protected resource",
+			&ChangeGuard{ProtectedAddresses: []string{"aws_s3_bucket.logs"}},
+			nil,
+			nil,
+		},
+		{
+			"max deletions exceeded",
+			&ChangeGuard{MaxDeletions: 1},
+			nil,
+			[]string{"aws_db_instance.main", `module.app.aws_instance.web["a"]`},
+		},
+		{
+			"max deletions not exceeded",
+			&ChangeGuard{MaxDeletions: 2},
+			nil,
+			nil,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.guard.Check(plan)
+			if c.deleted == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %s", err)
+				}
+				return
+			}
+
+			var e *ErrDestructiveChange
+			if !errors.As(err, &e) {
+				t.Fatalf("expected ErrDestructiveChange, got %T %s", err, err)
+			}
+			if diff := cmp.Diff(c.protected, e.Protected); diff != "" {
+				t.Fatalf("protected mismatch (-expected +actual):\n%s", diff)
+			}
+			if diff := cmp.Diff(c.deleted, e.Deleted); diff != "" {
+				t.Fatalf("deleted mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMatchAddress(t *testing.T) {
+	for _, c := range []struct {
+		pattern string
+		addr    string
+		match   bool
+	}{
+		{"aws_instance.web", "aws_instance.web", true},
+		{"aws_instance.web", "aws_instance.web2", false},
+		{`aws_instance.web["a"]`, `aws_instance.web["a"]`, true},
+		{"aws_instance.*", `aws_instance.web["a"]`, true},
+		{"*.aws_db_instance.*", "module.db.aws_db_instance.main", true},
+		{"*.aws_db_instance.*", "aws_db_instance.main", false},
+		{"module.*.aws_instance.web", "module.app.aws_instance.web", true},
+		{"module.*.aws_instance.web", "module.app.aws_instance.api", false},
+		{"*", "anything", true},
+	} {
+		t.Run(c.pattern+" "+c.addr, func(t *testing.T) {
+			if actual := matchAddress(c.pattern, c.addr); actual != c.match {
+				t.Fatalf("expected %t, got %t", c.match, actual)
+			}
+		})
+	}
+}
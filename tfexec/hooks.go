@@ -0,0 +1,88 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+// CommandRun describes a Terraform command about to run, as passed to
+// BeforeRun hooks.
+type CommandRun struct {
+	// Subcommand is the subcommand being run, e.g. "plan" or "state pull".
+	// Changing it has no effect.
+	Subcommand string
+
+	// Args are the arguments passed to Terraform, excluding the executable.
+	// Hooks may change them.
+	Args []string
+
+	// Env is the environment Terraform runs with. Hooks may change it, e.g. to
+	// inject credentials.
+	Env map[string]string
+
+	// WorkingDir is the directory Terraform runs in. Changing it has no
+	// effect.
+	WorkingDir string
+}
+
+// BeforeRunFunc is called before a Terraform command runs, see BeforeRun. It
+// may change the command's arguments and environment, or return an error to
+// prevent the command from running.
+type BeforeRunFunc func(ctx context.Context, run *CommandRun) error
+
+// AfterRunFunc is called after a Terraform command has run or was prevented
+// from running, see AfterRun.
+type AfterRunFunc func(ctx context.Context, info *RunInfo)
+
+// BeforeRun adds hooks called before every Terraform command run by this
+// instance, in the order they were added.
+//
+// If a hook returns an error, the remaining hooks are not called, the
+// command does not run and the error is returned by the method running it,
+// wrapped. This allows building e.g. dry-run modes or approval gates outside
+// of the library.
+func (tf *Terraform) BeforeRun(hooks ...BeforeRunFunc) {
+	tf.beforeRun = append(tf.beforeRun, hooks...)
+}
+
+// AfterRun adds hooks called after every Terraform command run by this
+// instance, including failed commands and those prevented from running by a
+// BeforeRun hook. Hooks are called in the reverse order they were added, so
+// that a pair of hooks added by the same middleware wraps those added later.
+//
+// AfterRun hooks are called synchronously, before the result of the command
+// is returned, and after the callback set via SetRunInfoCallback.
+func (tf *Terraform) AfterRun(hooks ...AfterRunFunc) {
+	tf.afterRun = append(tf.afterRun, hooks...)
+}
+
+// runBeforeHooks calls the BeforeRun hooks for cmd, which must not have been
+// started yet, and applies their changes to it.
+func (tf *Terraform) runBeforeHooks(ctx context.Context, cmd *exec.Cmd) error {
+	if len(tf.beforeRun) == 0 {
+		return nil
+	}
+
+	run := &CommandRun{
+		Subcommand: subcommandOf(cmd.Args[1:]),
+		Args:       append([]string{}, cmd.Args[1:]...),
+		Env:        envMap(cmd.Env),
+		WorkingDir: cmd.Dir,
+	}
+
+	for _, hook := range tf.beforeRun {
+		err := hook(ctx, run)
+		if err != nil {
+			return fmt.Errorf("terraform %s prevented from running: %w", run.Subcommand, err)
+		}
+	}
+
+	cmd.Args = append(cmd.Args[:1:1], run.Args...)
+	cmd.Env = envSlice(run.Env)
+
+	return nil
+}
@@ -5,6 +5,7 @@ package tfexec
 
 import (
 	"context"
+	"fmt"
 	"os/exec"
 	"strconv"
 )
@@ -15,6 +16,7 @@ type importConfig struct {
 	backup             string
 	config             string
 	allowMissingConfig bool
+	identity           map[string]interface{}
 	lock               bool
 	lockTimeout        string
 	reattachInfo       ReattachInfo
@@ -47,6 +49,10 @@ func (opt *AllowMissingConfigOption) configureImport(conf *importConfig) {
 	conf.allowMissingConfig = opt.allowMissingConfig
 }
 
+func (opt *IdentityOption) configureImport(conf *importConfig) {
+	conf.identity = opt.identity
+}
+
 func (opt *LockOption) configureImport(conf *importConfig) {
 	conf.lock = opt.lock
 }
@@ -76,7 +82,22 @@ func (opt *VarFileOption) configureImport(conf *importConfig) {
 }
 
 // Import represents the terraform import subcommand.
+//
+// If the Identity option is given, id must be empty and the resource is
+// imported by its identity instead. As the import subcommand only supports
+// import IDs, this is done with an import block, which requires Terraform
+// 1.12 and configuration for the resource, see BulkImport. The plan applying
+// the import is targeted at the resource, and it is not applied if it would
+// change anything else, including the imported object itself.
 func (tf *Terraform) Import(ctx context.Context, address, id string, opts ...ImportOption) error {
+	c := defaultImportOptions
+	for _, o := range opts {
+		o.configureImport(&c)
+	}
+	if c.identity != nil {
+		return tf.importIdentity(ctx, address, id, c)
+	}
+
 	cmd, err := tf.importCmd(ctx, address, id, opts...)
 	if err != nil {
 		return err
@@ -142,3 +163,28 @@ func (tf *Terraform) importCmd(ctx context.Context, address, id string, opts ...
 
 	return tf.buildTerraformCmd(ctx, mergeEnv, args...), nil
 }
+
+func (tf *Terraform) importIdentity(ctx context.Context, address, id string, c importConfig) error {
+	if id != "" {
+		return fmt.Errorf("import ID must be empty when importing by identity")
+	}
+	if c.config != "" || c.allowMissingConfig {
+		return fmt.Errorf("Config and AllowMissingConfig options are not supported when importing by identity")
+	}
+
+	bc := defaultBulkImportOptions
+	bc.apply = true
+	bc.generateConfig = false
+	bc.stateOnly = true
+	bc.backup = c.backup
+	bc.lock = c.lock
+	bc.lockTimeout = c.lockTimeout
+	bc.reattachInfo = c.reattachInfo
+	bc.state = c.state
+	bc.stateOut = c.stateOut
+	bc.vars = c.vars
+	bc.varFiles = c.varFiles
+
+	_, err := tf.bulkImport(ctx, []ImportSpec{{Address: address, Identity: c.identity}}, bc)
+	return err
+}
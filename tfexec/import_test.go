@@ -72,3 +72,28 @@ func TestImportCmd(t *testing.T) {
 		}, nil, importCmd)
 	})
 }
+
+func TestImportIdentity(t *testing.T) {
+	td := t.TempDir()
+
+	tf, err := NewTerraform(td, tfVersion(t, testutil.Latest_v1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity := Identity(map[string]interface{}{"id": "i-123"})
+
+	t.Run("with id", func(t *testing.T) {
+		err := tf.Import(context.Background(), "aws_instance.web", "i-123", identity)
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+
+	t.Run("with allow missing config", func(t *testing.T) {
+		err := tf.Import(context.Background(), "aws_instance.web", "", identity, AllowMissingConfig(true))
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+}
@@ -23,6 +23,7 @@ type initConfig struct {
 	pluginDir     []string
 	reattachInfo  ReattachInfo
 	reconfigure   bool
+	retryPolicy   *RetryPolicy
 	upgrade       bool
 	verifyPlugins bool
 }
@@ -92,6 +93,10 @@ func (opt *ReconfigureOption) configureInit(conf *initConfig) {
 	conf.reconfigure = opt.reconfigure
 }
 
+func (opt *RetryOption) configureInit(conf *initConfig) {
+	conf.retryPolicy = opt.policy
+}
+
 func (opt *UpgradeOption) configureInit(conf *initConfig) {
 	conf.upgrade = opt.upgrade
 }
@@ -117,11 +122,18 @@ func (tf *Terraform) configureInitOptions(ctx context.Context, c *initConfig, op
 
 // Init represents the terraform init subcommand.
 func (tf *Terraform) Init(ctx context.Context, opts ...InitOption) error {
-	cmd, err := tf.initCmd(ctx, opts...)
-	if err != nil {
-		return err
+	c := defaultInitOptions
+	for _, o := range opts {
+		o.configureInit(&c)
 	}
-	return tf.runTerraformCmd(ctx, cmd)
+
+	return tf.withRetry(ctx, c.retryPolicy, "init", func() error {
+		cmd, err := tf.initCmd(ctx, opts...)
+		if err != nil {
+			return err
+		}
+		return tf.runTerraformCmd(ctx, cmd)
+	})
 }
 
 // InitJSON represents the terraform init subcommand with the `-json` flag.
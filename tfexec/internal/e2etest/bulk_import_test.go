@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package e2etest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+)
+
+func TestBulkImport(t *testing.T) {
+	runTest(t, "empty_with_tf_file", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		if tfv.LessThan(generateConfigOutMinVersion) {
+			t.Skip("import blocks were added in Terraform 1.5.0, so test is not valid")
+		}
+
+		err := tf.Init(context.Background())
+		if err != nil {
+			t.Fatalf("error running Init in test directory: %s", err)
+		}
+
+		specs := []tfexec.ImportSpec{
+			{Address: "terraform_data.foo", ID: "bar"},
+		}
+
+		result, err := tf.BulkImport(context.Background(), specs)
+		if err != nil {
+			t.Fatalf("error running BulkImport: %s", err)
+		}
+		if !strings.Contains(result.GeneratedConfig, `resource "terraform_data" "foo"`) {
+			t.Fatalf("expected generated configuration for terraform_data.foo, got %q", result.GeneratedConfig)
+		}
+		if result.Applied {
+			t.Fatal("expected plan not to be applied")
+		}
+
+		entries, err := os.ReadDir(tf.WorkingDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range entries {
+			if strings.HasPrefix(e.Name(), "tfexec-import-") {
+				t.Fatalf("expected temporary file %s to be removed", e.Name())
+			}
+		}
+
+		result, err = tf.BulkImport(context.Background(), specs, tfexec.ApplyImports(true), tfexec.GenerateConfigOut("generated.tf"))
+		if err != nil {
+			t.Fatalf("error running BulkImport: %s", err)
+		}
+		if !result.Applied {
+			t.Fatal("expected plan to be applied")
+		}
+		if _, err := os.Stat(filepath.Join(tf.WorkingDir(), "generated.tf")); err != nil {
+			t.Fatalf("expected generated.tf to be kept: %s", err)
+		}
+
+		state, err := tf.Show(context.Background())
+		if err != nil {
+			t.Fatalf("error running Show: %s", err)
+		}
+		if state.Values == nil || len(state.Values.RootModule.Resources) != 1 || state.Values.RootModule.Resources[0].Address != "terraform_data.foo" {
+			t.Fatalf("expected terraform_data.foo to be imported, got %#v", state.Values)
+		}
+	})
+}
+
+func TestBulkImport_pendingChanges(t *testing.T) {
+	runTest(t, "import_pending_changes", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		if tfv.LessThan(generateConfigOutMinVersion) {
+			t.Skip("import blocks were added in Terraform 1.5.0, so test is not valid")
+		}
+
+		err := tf.Init(context.Background())
+		if err != nil {
+			t.Fatalf("error running Init in test directory: %s", err)
+		}
+
+		specs := []tfexec.ImportSpec{
+			{Address: "terraform_data.foo", ID: "bar"},
+		}
+
+		result, err := tf.BulkImport(context.Background(), specs, tfexec.ApplyImports(true), tfexec.GenerateConfigOut("generated.tf"))
+		if err != nil {
+			t.Fatalf("error running BulkImport: %s", err)
+		}
+		if !result.Applied {
+			t.Fatal("expected plan to be applied")
+		}
+
+		state, err := tf.Show(context.Background())
+		if err != nil {
+			t.Fatalf("error running Show: %s", err)
+		}
+		if state.Values == nil || len(state.Values.RootModule.Resources) != 1 || state.Values.RootModule.Resources[0].Address != "terraform_data.foo" {
+			t.Fatalf("expected only terraform_data.foo to be imported, got %#v", state.Values)
+		}
+	})
+}
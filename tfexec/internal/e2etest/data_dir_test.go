@@ -0,0 +1,44 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package e2etest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/hashicorp/terraform-exec/tfexec/internal/testutil"
+)
+
+func TestCloneDataDir_modules(t *testing.T) {
+	runTestWithVersions(t, []string{testutil.Latest_v1}, "data_dir_modules", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		err := tf.Init(context.Background())
+		if err != nil {
+			t.Fatalf("error running Init in test directory: %s", err)
+		}
+
+		ctf, err := tf.CloneDataDir("variant-a")
+		if err != nil {
+			t.Fatalf("error cloning data directory: %s", err)
+		}
+
+		// the clone must not depend on the modules installed in the original
+		err = os.RemoveAll(filepath.Join(tf.DataDir(), "modules"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := ctf.Validate(context.Background())
+		if err != nil {
+			t.Fatalf("error running Validate with cloned data directory: %s", err)
+		}
+		if !out.Valid {
+			t.Fatalf("expected configuration to be valid with cloned data directory, got %#v", out.Diagnostics)
+		}
+	})
+}
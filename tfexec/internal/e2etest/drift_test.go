@@ -0,0 +1,54 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package e2etest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+)
+
+var refreshOnlyMinVersion = version.Must(version.NewVersion("0.15.4"))
+
+func TestDetectDrift(t *testing.T) {
+	runTest(t, "basic", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		if tfv.LessThan(refreshOnlyMinVersion) {
+			t.Skip("refresh-only plans were added in Terraform 0.15.4, so test is not valid")
+		}
+
+		err := tf.Init(context.Background())
+		if err != nil {
+			t.Fatalf("error running Init in test directory: %s", err)
+		}
+
+		err = tf.Apply(context.Background())
+		if err != nil {
+			t.Fatalf("error running Apply: %s", err)
+		}
+
+		before, err := tf.StatePull(context.Background())
+		if err != nil {
+			t.Fatalf("error running StatePull: %s", err)
+		}
+
+		report, err := tf.DetectDrift(context.Background())
+		if err != nil {
+			t.Fatalf("error running DetectDrift: %s", err)
+		}
+		if !report.NoDrift {
+			t.Fatalf("expected no drift, got %#v", report.Resources)
+		}
+
+		after, err := tf.StatePull(context.Background())
+		if err != nil {
+			t.Fatalf("error running StatePull: %s", err)
+		}
+		if before != after {
+			t.Fatal("expected state not to be modified")
+		}
+	})
+}
@@ -5,17 +5,21 @@ package e2etest
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/go-version"
 
 	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/hashicorp/terraform-exec/tfexec/internal/testutil"
 )
 
 func TestFormatString(t *testing.T) {
@@ -77,11 +81,15 @@ func TestFormatCheck(t *testing.T) {
 
 func TestFormatWrite(t *testing.T) {
 	runTest(t, "unformatted", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
-		err := tf.FormatWrite(context.Background())
+		files, err := tf.FormatWrite(context.Background())
 		if err != nil {
 			t.Fatalf("error from FormatWrite: %T %q", err, err)
 		}
 
+		if !reflect.DeepEqual(files, []string{"file1.tf", "file2.tf"}) {
+			t.Fatalf("unexpected files list: %#v", files)
+		}
+
 		for file, golden := range map[string]string{
 			"file1.tf": "file1.golden.txt",
 			"file2.tf": "file2.golden.txt",
@@ -91,6 +99,56 @@ func TestFormatWrite(t *testing.T) {
 	})
 }
 
+func TestFormatWrite_fileKinds(t *testing.T) {
+	runTestWithVersions(t, []string{testutil.Latest_v1}, "unformatted_kinds", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		files, err := tf.FormatWrite(context.Background())
+		if err != nil {
+			t.Fatalf("error from FormatWrite: %T %q", err, err)
+		}
+
+		expected := []string{"main.tfmock.hcl", "main.tftest.hcl", "terraform.tfvars"}
+		if !reflect.DeepEqual(files, expected) {
+			t.Fatalf("unexpected files list: %#v", files)
+		}
+
+		files, err = tf.FormatWrite(context.Background())
+		if err != nil {
+			t.Fatalf("error from FormatWrite: %T %q", err, err)
+		}
+		if len(files) != 0 {
+			t.Fatalf("expected no files to be rewritten, got %#v", files)
+		}
+	})
+}
+
+func TestFormatDiff(t *testing.T) {
+	runTestWithVersions(t, []string{testutil.Latest_v1}, "unformatted_kinds", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		checksum := checkSum(t, filepath.Join(tf.WorkingDir(), "terraform.tfvars"))
+
+		diffs, err := tf.FormatDiff(context.Background())
+		if err != nil {
+			t.Fatalf("error from FormatDiff: %T %q", err, err)
+		}
+
+		var files []string
+		for _, d := range diffs {
+			files = append(files, d.File)
+		}
+		expected := []string{"main.tfmock.hcl", "main.tftest.hcl", "terraform.tfvars"}
+		if !reflect.DeepEqual(files, expected) {
+			t.Fatalf("unexpected files list: %#v", files)
+		}
+
+		if !strings.Contains(diffs[2].Diff, "-foo    =   \"bar\"\n+foo = \"bar\"\n") {
+			t.Fatalf("unexpected diff:\n%s", diffs[2].Diff)
+		}
+
+		if checksum != checkSum(t, filepath.Join(tf.WorkingDir(), "terraform.tfvars")) {
+			t.Fatal("terraform.tfvars should not have changed")
+		}
+	})
+}
+
 func TestFormat(t *testing.T) {
 	runTest(t, "", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
 		unformatted := strings.TrimSpace(`
@@ -123,6 +181,42 @@ resource "foo" "bar" {
 	})
 }
 
+func TestFormatter(t *testing.T) {
+	runTest(t, "", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		f := tfexec.NewFormatter(tf, 2)
+
+		var snippets, expected []string
+		for i := 0; i < 50; i++ {
+			snippets = append(snippets, fmt.Sprintf("resource     \"foo\"      \"bar%d\" {\n\tbaz = %d\n\t\tqux      =        2\n}\n", i, i))
+			expected = append(expected, fmt.Sprintf("resource \"foo\" \"bar%d\" {\n  baz = %d\n  qux = 2\n}\n", i, i))
+		}
+		snippets = append(snippets, "invalid = ")
+
+		var wg sync.WaitGroup
+		for g := 0; g < 3; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				formatted, err := f.FormatBatch(context.Background(), snippets)
+				var e *tfexec.ErrFormatSnippets
+				if !errors.As(err, &e) || len(e.Errors) != 1 || e.Errors[50] == nil {
+					t.Errorf("expected error for the invalid snippet only, got %v", err)
+					return
+				}
+
+				for i, exp := range expected {
+					if strings.TrimSpace(formatted[i]) != strings.TrimSpace(exp) {
+						t.Errorf("snippet %d: expected:\n%s\ngot:\n%s\n", i, exp, formatted[i])
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
 func TestFormat_warmFormatter(t *testing.T) {
 	runTest(t, "", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
 		unformatted := strings.TrimSpace(`
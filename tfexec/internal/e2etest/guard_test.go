@@ -0,0 +1,58 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package e2etest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+)
+
+func TestGuard_destroy(t *testing.T) {
+	runTest(t, "basic", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		if tfv.LessThan(showMinVersion) {
+			t.Skip("terraform show was added in Terraform 0.12, so test is not valid")
+		}
+
+		err := tf.Init(context.Background())
+		if err != nil {
+			t.Fatalf("error running Init in test directory: %s", err)
+		}
+
+		err = tf.Apply(context.Background())
+		if err != nil {
+			t.Fatalf("error running Apply: %s", err)
+		}
+
+		guard := &tfexec.ChangeGuard{
+			ProtectedTypes: []string{"null_resource"},
+		}
+
+		err = tf.Destroy(context.Background(), tfexec.Guard(guard))
+		var e *tfexec.ErrDestructiveChange
+		if !errors.As(err, &e) {
+			t.Fatalf("expected ErrDestructiveChange, got %T %s", err, err)
+		}
+		if len(e.Protected) != 1 || e.Protected[0] != "null_resource.foo" {
+			t.Fatalf("unexpected protected resources: %#v", e.Protected)
+		}
+
+		state, err := tf.Show(context.Background())
+		if err != nil {
+			t.Fatalf("error running Show: %s", err)
+		}
+		if state.Values == nil || len(state.Values.RootModule.Resources) != 1 {
+			t.Fatalf("expected resource to remain in state, got %#v", state.Values)
+		}
+
+		err = tf.Destroy(context.Background(), tfexec.Guard(&tfexec.ChangeGuard{MaxDeletions: 1}))
+		if err != nil {
+			t.Fatalf("error running Destroy: %s", err)
+		}
+	})
+}
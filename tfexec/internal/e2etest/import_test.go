@@ -5,11 +5,19 @@ package e2etest
 
 import (
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 
 	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/hashicorp/terraform-exec/tfexec/internal/testutil"
 )
 
 func TestImport(t *testing.T) {
@@ -67,3 +75,102 @@ func TestImport(t *testing.T) {
 		t.Fatalf("imported resource %q not found", resourceAddress)
 	})
 }
+
+func TestImport_identityPendingChanges(t *testing.T) {
+	runTestWithVersions(t, []string{testutil.Latest_v1}, "import_identity", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		ctx := context.Background()
+
+		err := tf.Init(ctx)
+		if err != nil {
+			t.Fatalf("error running Init in test directory: %s", err)
+		}
+
+		// generate configuration and import blocks for the pets found, outside
+		// of the working directory so that they are not planned
+		result, err := tf.Query(ctx, tfexec.GenerateConfigOut(filepath.Join(t.TempDir(), "generated.tf")))
+		if err != nil {
+			t.Fatalf("error running Query: %s", err)
+		}
+
+		var address string
+		var identity map[string]interface{}
+		for _, b := range result.GeneratedConfig {
+			if b.Type == "import" {
+				address, identity = importBlockTarget(t, b.Source)
+				break
+			}
+		}
+		if address == "" {
+			t.Fatalf("expected generated import block, got %#v", result.GeneratedConfig)
+		}
+
+		var src []string
+		for _, b := range result.GeneratedConfig {
+			if b.Type == "resource" && strings.Join(b.Labels, ".") == address {
+				src = append(src, b.Source)
+			}
+		}
+		err = os.WriteFile(filepath.Join(tf.WorkingDir(), "imported.tf"), []byte(strings.Join(src, "\n")+"\n"), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = tf.Import(ctx, address, "", tfexec.Identity(identity))
+		if err != nil {
+			t.Fatalf("error running Import: %s", err)
+		}
+
+		state, err := tf.Show(ctx)
+		if err != nil {
+			t.Fatalf("error running Show: %s", err)
+		}
+		if state.Values == nil || len(state.Values.RootModule.Resources) != 1 || state.Values.RootModule.Resources[0].Address != address {
+			t.Fatalf("expected only %s to be imported, without applying terraform_data.unrelated, got %#v", address, state.Values)
+		}
+	})
+}
+
+// importBlockTarget returns the address and identity of a generated import
+// block.
+func importBlockTarget(t *testing.T, src string) (string, map[string]interface{}) {
+	t.Helper()
+
+	f, diags := hclsyntax.ParseConfig([]byte(src), "generated.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	attrs, diags := f.Body.(*hclsyntax.Body).Blocks[0].Body.JustAttributes()
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	to, diags := hcl.AbsTraversalForExpr(attrs["to"].Expr)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	var address []string
+	for _, step := range to {
+		switch step := step.(type) {
+		case hcl.TraverseRoot:
+			address = append(address, step.Name)
+		case hcl.TraverseAttr:
+			address = append(address, step.Name)
+		}
+	}
+
+	value, diags := attrs["identity"].Expr.Value(nil)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	raw, err := ctyjson.SimpleJSONValue{Value: value}.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var identity map[string]interface{}
+	err = json.Unmarshal(raw, &identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return strings.Join(address, "."), identity
+}
@@ -0,0 +1,56 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package e2etest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/hashicorp/terraform-exec/tfexec/internal/testutil"
+)
+
+func TestLogCapture(t *testing.T) {
+	runTestWithVersions(t, []string{testutil.Latest_v1}, "basic", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		err := tf.Init(context.Background())
+		if err != nil {
+			t.Fatalf("error running Init in test directory: %s", err)
+		}
+
+		var captured []*tfexec.CommandLogs
+		err = tf.SetLogCapture(&tfexec.LogCapture{
+			Callback: func(logs *tfexec.CommandLogs) {
+				captured = append(captured, logs)
+			},
+			AttachToError: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = tf.Plan(context.Background())
+		if err != nil {
+			t.Fatalf("error running Plan: %s", err)
+		}
+
+		if len(captured) != 1 || captured[0].Subcommand != "plan" {
+			t.Fatalf("expected logs of plan to be captured, got %#v", captured)
+		}
+		if len(captured[0].Core) == 0 || len(captured[0].Provider) == 0 {
+			t.Fatalf("expected core and provider logs, got %d and %d records", len(captured[0].Core), len(captured[0].Provider))
+		}
+
+		_, err = tf.Plan(context.Background(), tfexec.Target("[invalid"))
+		var logsErr *tfexec.ErrWithLogs
+		if !errors.As(err, &logsErr) {
+			t.Fatalf("expected ErrWithLogs, got %#v", err)
+		}
+		if logsErr.Logs != captured[1] || len(logsErr.Logs.Core) == 0 {
+			t.Fatalf("expected captured logs to be attached, got %#v", logsErr.Logs)
+		}
+	})
+}
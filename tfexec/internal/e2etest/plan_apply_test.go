@@ -0,0 +1,73 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package e2etest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+)
+
+func TestPlanAndApply(t *testing.T) {
+	runTest(t, "basic", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		if tfv.LessThan(showMinVersion) {
+			t.Skip("terraform show was added in Terraform 0.12, so test is not valid")
+		}
+
+		err := tf.Init(context.Background())
+		if err != nil {
+			t.Fatalf("error running Init in test directory: %s", err)
+		}
+
+		t.Run("rejected", func(t *testing.T) {
+			applied, err := tf.PlanAndApply(context.Background(), nil, nil, func(plan *tfjson.Plan) bool {
+				return false
+			})
+			if err != nil {
+				t.Fatalf("error running PlanAndApply: %s", err)
+			}
+			if applied {
+				t.Fatal("expected rejected plan not to be applied")
+			}
+
+			state, err := tf.Show(context.Background())
+			if err != nil {
+				t.Fatalf("error running Show: %s", err)
+			}
+			if state.Values != nil {
+				t.Fatalf("expected empty state, got %#v", state.Values)
+			}
+		})
+
+		t.Run("approved", func(t *testing.T) {
+			var approved *tfjson.Plan
+			applied, err := tf.PlanAndApply(context.Background(), nil, nil, func(plan *tfjson.Plan) bool {
+				approved = plan
+				return true
+			})
+			if err != nil {
+				t.Fatalf("error running PlanAndApply: %s", err)
+			}
+			if !applied {
+				t.Fatal("expected approved plan to be applied")
+			}
+
+			if len(approved.ResourceChanges) != 1 || approved.ResourceChanges[0].Address != "null_resource.foo" {
+				t.Fatalf("unexpected resource changes in plan: %#v", approved.ResourceChanges)
+			}
+
+			state, err := tf.Show(context.Background())
+			if err != nil {
+				t.Fatalf("error running Show: %s", err)
+			}
+			if state.Values == nil || len(state.Values.RootModule.Resources) != 1 {
+				t.Fatalf("expected one resource in state, got %#v", state.Values)
+			}
+		})
+	})
+}
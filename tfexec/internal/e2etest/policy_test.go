@@ -0,0 +1,78 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package e2etest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+)
+
+func TestPolicy_apply(t *testing.T) {
+	runTest(t, "basic", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		if tfv.LessThan(showMinVersion) {
+			t.Skip("terraform show was added in Terraform 0.12, so test is not valid")
+		}
+
+		err := tf.Init(context.Background())
+		if err != nil {
+			t.Fatalf("error running Init in test directory: %s", err)
+		}
+
+		deny := tfexec.PolicyFunc(func(ctx context.Context, input *tfexec.PolicyInput) ([]tfexec.PolicyResult, error) {
+			var results []tfexec.PolicyResult
+			for _, rc := range input.Plan.ResourceChanges {
+				if rc.Type == "null_resource" {
+					results = append(results, tfexec.PolicyResult{
+						Policy:  "no-null-resources",
+						Status:  tfexec.PolicyFail,
+						Message: rc.Address + " is not allowed",
+					})
+				}
+			}
+			return results, nil
+		})
+
+		err = tf.Apply(context.Background(), tfexec.Policy(deny))
+		var e *tfexec.ErrPolicyFailed
+		if !errors.As(err, &e) {
+			t.Fatalf("expected ErrPolicyFailed, got %T %s", err, err)
+		}
+		if len(e.Report.Failures()) != 1 {
+			t.Fatalf("expected 1 failure, got %#v", e.Report.Results)
+		}
+
+		state, err := tf.Show(context.Background())
+		if err != nil {
+			t.Fatalf("error running Show: %s", err)
+		}
+		if state.Values != nil && len(state.Values.RootModule.Resources) > 0 {
+			t.Fatalf("expected no resources to be applied, got %#v", state.Values.RootModule.Resources)
+		}
+
+		warn := tfexec.PolicyFunc(func(ctx context.Context, input *tfexec.PolicyInput) ([]tfexec.PolicyResult, error) {
+			return []tfexec.PolicyResult{{
+				Policy:  "workspace",
+				Status:  tfexec.PolicyWarn,
+				Message: "applying to " + input.Metadata.Workspace,
+			}}, nil
+		})
+
+		var report tfexec.PolicyReport
+		err = tf.Apply(context.Background(), tfexec.Policy(warn), tfexec.PolicyReportOut(&report))
+		if err != nil {
+			t.Fatalf("error running Apply: %s", err)
+		}
+		if report.Metadata.Workspace != "default" {
+			t.Fatalf("expected default workspace, got %q", report.Metadata.Workspace)
+		}
+		if len(report.Warnings()) != 1 || report.Warnings()[0].Message != "applying to default" {
+			t.Fatalf("unexpected results: %#v", report.Results)
+		}
+	})
+}
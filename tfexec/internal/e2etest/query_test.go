@@ -80,3 +80,50 @@ func TestQueryJSON_TF114(t *testing.T) {
 		}
 	})
 }
+
+func TestQuery_TF114(t *testing.T) {
+	versions := []string{testutil.Latest_Alpha_v1_14}
+
+	runTestWithVersions(t, versions, "query", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		err := tf.Init(context.Background())
+		if err != nil {
+			t.Fatalf("error running Init in test directory: %s", err)
+		}
+
+		result, err := tf.Query(context.Background(), tfexec.GenerateConfigOut("generated.tf"))
+		if err != nil {
+			t.Fatalf("error running Query: %s", err)
+		}
+
+		if len(result.Resources) != 5 {
+			t.Fatalf("expected 5 query results, got %d", len(result.Resources))
+		}
+		for _, r := range result.Resources {
+			if r.Address != "list.concept_pet.pets" || len(r.Identity) == 0 {
+				t.Fatalf("unexpected query result: %#v", r)
+			}
+		}
+		expectedLists := []tfjson.ListCompleteData{{
+			Address:      "list.concept_pet.pets",
+			ResourceType: "concept_pet",
+			Total:        5,
+		}}
+		if diff := cmp.Diff(expectedLists, result.Lists); diff != "" {
+			t.Fatalf("unexpected list summaries: %s", diff)
+		}
+		if len(result.GeneratedConfig) == 0 {
+			t.Fatal("expected generated configuration")
+		}
+
+		result, err = tf.Query(context.Background(), tfexec.Limit(2), tfexec.GenerateConfigOut("limited.tf"))
+		if err != nil {
+			t.Fatalf("error running Query: %s", err)
+		}
+		if len(result.Resources) != 2 || !result.Truncated {
+			t.Fatalf("expected 2 truncated query results, got %d (truncated: %t)", len(result.Resources), result.Truncated)
+		}
+		if len(result.GeneratedConfig) != 0 {
+			t.Fatalf("expected no generated configuration for truncated query, got %d blocks", len(result.GeneratedConfig))
+		}
+	})
+}
@@ -0,0 +1,8 @@
+module "local" {
+  source = "./local"
+}
+
+module "remote" {
+  source  = "vancluever/module/null"
+  version = "~> 1.0.1"
+}
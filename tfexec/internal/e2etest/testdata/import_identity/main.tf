@@ -0,0 +1,11 @@
+terraform {
+  required_providers {
+    concept = {
+      source  = "dbanck/concept"
+      version = "0.1.0"
+    }
+  }
+}
+
+resource "terraform_data" "unrelated" {
+}
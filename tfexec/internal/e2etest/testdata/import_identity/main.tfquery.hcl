@@ -0,0 +1,3 @@
+list "concept_pet" "pets" {
+    provider = concept
+}
@@ -0,0 +1,3 @@
+mock_resource    "foo_bar" {
+    defaults = {}
+}
@@ -5,6 +5,7 @@ package e2etest
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -162,3 +163,66 @@ func assertWorkspaceShow(t *testing.T, tf *tfexec.Terraform, expectedWorkspace s
 		t.Fatalf("expected %q workspace, got %q workspace", expectedWorkspace, actualWorkspace)
 	}
 }
+
+func TestWorkspace_ensure(t *testing.T) {
+	runTest(t, "basic", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		const newWorkspace = "ensured"
+
+		t.Run("ensure missing workspace", func(t *testing.T) {
+			err := tf.EnsureWorkspace(context.Background(), newWorkspace)
+			if err != nil {
+				t.Fatalf("got error ensuring workspace: %s", err)
+			}
+
+			assertWorkspaceList(t, tf, newWorkspace, newWorkspace)
+			assertWorkspaceShow(t, tf, newWorkspace)
+		})
+
+		t.Run("ensure existing workspace", func(t *testing.T) {
+			err := tf.WorkspaceSelect(context.Background(), defaultWorkspace)
+			if err != nil {
+				t.Fatalf("unable to select workspace: %s", err)
+			}
+
+			err = tf.EnsureWorkspace(context.Background(), newWorkspace)
+			if err != nil {
+				t.Fatalf("got error ensuring workspace: %s", err)
+			}
+
+			assertWorkspaceList(t, tf, newWorkspace, newWorkspace)
+			assertWorkspaceShow(t, tf, newWorkspace)
+		})
+	})
+}
+
+func TestWorkspace_with(t *testing.T) {
+	runTest(t, "basic", func(t *testing.T, tfv *version.Version, tf *tfexec.Terraform) {
+		const tempWorkspace = "temporary"
+
+		fnErr := errors.New("failed inside workspace")
+
+		err := tf.WithWorkspace(context.Background(), tempWorkspace, func() error {
+			assertWorkspaceShow(t, tf, tempWorkspace)
+			return fnErr
+		})
+		if !errors.Is(err, fnErr) {
+			t.Fatalf("expected error from fn, got %v", err)
+		}
+
+		assertWorkspaceList(t, tf, defaultWorkspace, tempWorkspace)
+		assertWorkspaceShow(t, tf, defaultWorkspace)
+
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Fatal("expected panic from fn")
+				}
+			}()
+			_ = tf.WithWorkspace(context.Background(), tempWorkspace, func() error {
+				panic("panicking inside workspace")
+			})
+		}()
+
+		assertWorkspaceShow(t, tf, defaultWorkspace)
+	})
+}
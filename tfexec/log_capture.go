@@ -0,0 +1,214 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// LogRecord is a single record of Terraform's JSON log output.
+type LogRecord struct {
+	Level     string
+	Message   string
+	Module    string
+	Timestamp time.Time
+
+	// Fields are the remaining fields of the record, if any.
+	Fields map[string]interface{}
+}
+
+// CommandLogs are the logs captured for a single Terraform command, see
+// SetLogCapture.
+type CommandLogs struct {
+	// Subcommand is the subcommand run, e.g. "plan" or "state pull".
+	Subcommand string
+
+	// Core are the records logged by Terraform itself.
+	Core []LogRecord
+
+	// Provider are the records logged by provider plugins.
+	Provider []LogRecord
+}
+
+// LogCapture configures the capture of Terraform's logs for each command, see
+// SetLogCapture.
+type LogCapture struct {
+	// Callback, if set, is called with the logs of every command once it has
+	// finished, including failed commands. If the logs cannot be read
+	// completely, it is called with the records read.
+	Callback func(*CommandLogs)
+
+	// AttachToError attaches the logs of failed commands to the returned
+	// error, as an *ErrWithLogs.
+	AttachToError bool
+}
+
+// ErrWithLogs is returned instead of the error of a failed command when
+// logs are captured with LogCapture.AttachToError set. The message is that of
+// the original error.
+type ErrWithLogs struct {
+	Logs *CommandLogs
+
+	// ReadErr is the error reading the captured logs, if any, in which case
+	// Logs only contains the records read before it occurred.
+	ReadErr error
+
+	err error
+}
+
+func (e *ErrWithLogs) Error() string {
+	return e.err.Error()
+}
+
+func (e *ErrWithLogs) Unwrap() error {
+	return e.err
+}
+
+// SetLogCapture enables capturing the logs of each Terraform command run by
+// this instance separately, so that concurrent commands do not interleave as
+// they do with SetLogPath. Pass nil to disable it, which is the default.
+//
+// Each command logs in JSON to its own temporary file, which is parsed once
+// the command has finished and then removed. This takes precedence over
+// SetLog and SetLogPath, while the levels set via SetLogCore and
+// SetLogProvider still apply. Otherwise everything is logged at TRACE level,
+// so captured logs may be large.
+//
+// This is only compatible with Terraform CLI 0.15.0 or later.
+func (tf *Terraform) SetLogCapture(capture *LogCapture) error {
+	if capture != nil {
+		err := tf.compatible(context.Background(), tf0_15_0, nil)
+		if err != nil {
+			return err
+		}
+	}
+	tf.logCapture = capture
+	return nil
+}
+
+// startLogCapture makes cmd, which must not have been started yet, log to a
+// temporary file, and returns the function processing the logs once it has
+// finished with the given error, which returns the error to report.
+func (tf *Terraform) startLogCapture(ctx context.Context, cmd *exec.Cmd) (func(error) error, error) {
+	capture := tf.logCapture
+	if capture == nil || (capture.Callback == nil && !capture.AttachToError) {
+		return func(err error) error { return err }, nil
+	}
+
+	f, err := os.CreateTemp("", "terraform-log-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create log file: %w", err)
+	}
+	path := f.Name()
+	f.Close()
+
+	// later values take precedence over those set by buildEnv
+	cmd.Env = append(cmd.Env,
+		logEnvVar+"=JSON",
+		logPathEnvVar+"="+path,
+		logCoreEnvVar+"="+tf.logCore,
+		logProviderEnvVar+"="+tf.logProvider,
+	)
+	subcommand := subcommandOf(cmd.Args[1:])
+
+	return func(err error) error {
+		defer os.Remove(path)
+
+		if capture.Callback == nil && err == nil {
+			return nil
+		}
+
+		// partially read logs are still passed on, e.g. if a line is too long
+		logs, readErr := readCommandLogs(path)
+		if readErr != nil {
+			tf.logf(ctx, slog.LevelWarn, []slog.Attr{slog.String("command", subcommand)},
+				"unable to read captured logs of terraform %s: %s", subcommand, readErr)
+		}
+		logs.Subcommand = subcommand
+
+		if capture.Callback != nil {
+			capture.Callback(logs)
+		}
+		if err != nil && capture.AttachToError {
+			return &ErrWithLogs{
+				Logs:    logs,
+				ReadErr: readErr,
+				err:     err,
+			}
+		}
+		return err
+	}, nil
+}
+
+// readCommandLogs reads the JSON log file at path, separating the records of
+// Terraform and its provider plugins. The records read so far are returned
+// along with any error.
+func readCommandLogs(path string) (*CommandLogs, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return &CommandLogs{}, err
+	}
+	defer f.Close()
+
+	return parseCommandLogs(f)
+}
+
+func parseCommandLogs(r io.Reader) (*CommandLogs, error) {
+	logs := &CommandLogs{}
+
+	scanner := bufio.NewScanner(r)
+	// trace logs may contain large payloads, e.g. provider schemas
+	scanner.Buffer(nil, 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		record := parseLogRecord(line)
+		if record.Module == "provider" || strings.HasPrefix(record.Module, "provider.") {
+			logs.Provider = append(logs.Provider, record)
+		} else {
+			logs.Core = append(logs.Core, record)
+		}
+	}
+
+	return logs, scanner.Err()
+}
+
+// parseLogRecord parses a line of Terraform's JSON log output. Lines which
+// are not JSON, e.g. output of panicking plugins, become the message of a
+// record without level.
+func parseLogRecord(line string) LogRecord {
+	var fields map[string]interface{}
+	if json.Unmarshal([]byte(line), &fields) != nil {
+		return LogRecord{Message: line}
+	}
+
+	var record LogRecord
+	record.Level, _ = fields["@level"].(string)
+	record.Message, _ = fields["@message"].(string)
+	record.Module, _ = fields["@module"].(string)
+	if ts, ok := fields["@timestamp"].(string); ok {
+		record.Timestamp, _ = time.Parse(time.RFC3339Nano, ts)
+	}
+
+	for _, k := range []string{"@level", "@message", "@module", "@timestamp"} {
+		delete(fields, k)
+	}
+	if len(fields) > 0 {
+		record.Fields = fields
+	}
+
+	return record
+}
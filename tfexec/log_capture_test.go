@@ -0,0 +1,65 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseCommandLogs(t *testing.T) {
+	input := `{"@level":"info","@message":"Terraform version: 1.9.0","@timestamp":"2024-06-26T14:02:12.012345+02:00"}
+{"@level":"debug","@message":"using provider","@module":"provider","@timestamp":"2024-06-26T14:02:13.000000+02:00"}
+
+{"@level":"trace","@message":"setting up","@module":"provider.terraform-provider-null_v3.2.2_x5","@timestamp":"2024-06-26T14:02:13.5+02:00","tf_rpc":"ConfigureProvider"}
+panic: boom
+{"@level":"warn","@message":"provider exited","@module":"plugin","@timestamp":"not a time"}
+`
+
+	logs, err := parseCommandLogs(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zone := time.FixedZone("", 2*60*60)
+	expected := &CommandLogs{
+		Core: []LogRecord{
+			{
+				Level:     "info",
+				Message:   "Terraform version: 1.9.0",
+				Timestamp: time.Date(2024, 6, 26, 14, 2, 12, 12345000, zone),
+			},
+			{
+				Message: "panic: boom",
+			},
+			{
+				Level:   "warn",
+				Message: "provider exited",
+				Module:  "plugin",
+			},
+		},
+		Provider: []LogRecord{
+			{
+				Level:     "debug",
+				Message:   "using provider",
+				Module:    "provider",
+				Timestamp: time.Date(2024, 6, 26, 14, 2, 13, 0, zone),
+			},
+			{
+				Level:     "trace",
+				Message:   "setting up",
+				Module:    "provider.terraform-provider-null_v3.2.2_x5",
+				Timestamp: time.Date(2024, 6, 26, 14, 2, 13, 500000000, zone),
+				Fields:    map[string]interface{}{"tf_rpc": "ConfigureProvider"},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, logs, cmp.Comparer(time.Time.Equal)); diff != "" {
+		t.Fatalf("unexpected logs: %s", diff)
+	}
+}
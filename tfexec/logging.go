@@ -0,0 +1,96 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"time"
+)
+
+// SetSlogLogger specifies a structured logger for tfexec to use instead of
+// the logger set via SetLogger. Pass nil to go back to that logger.
+//
+// Messages are logged at the level matching the bracketed prefix used with
+// SetLogger, e.g. slog.LevelWarn for "[WARN]". In addition, every Terraform
+// command is logged when it starts and finishes, with the following
+// attributes:
+//
+//   - command, the subcommand, e.g. "plan" or "state pull"
+//   - args, with the values of -var and -backend-config assignments redacted
+//   - dir, the working directory
+//   - pid, once Terraform has started
+//   - duration and exit_code, once it has finished, and error if it failed
+func (tf *Terraform) SetSlogLogger(logger *slog.Logger) {
+	tf.slogger = logger
+}
+
+// logf logs a message at the given level. It is formatted with the bracketed
+// level prefix for the logger set via SetLogger, while the structured logger
+// also receives attrs.
+func (tf *Terraform) logf(ctx context.Context, level slog.Level, attrs []slog.Attr, format string, v ...interface{}) {
+	if tf.slogger != nil {
+		tf.slogger.LogAttrs(ctx, level, fmt.Sprintf(format, v...), attrs...)
+		return
+	}
+	tf.logger.Printf("["+levelPrefix(level)+"] "+format, v...)
+}
+
+func levelPrefix(level slog.Level) string {
+	switch {
+	case level < slog.LevelInfo:
+		return "DEBUG"
+	case level < slog.LevelWarn:
+		return "INFO"
+	case level < slog.LevelError:
+		return "WARN"
+	}
+	return "ERROR"
+}
+
+// cmdAttrs returns the structured logging attributes describing cmd.
+func cmdAttrs(cmd *exec.Cmd) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("command", subcommandOf(cmd.Args[1:])),
+		slog.Any("args", redactArgs(cmd.Args[1:])),
+		slog.String("dir", cmd.Dir),
+	}
+	if cmd.Process != nil {
+		attrs = append(attrs, slog.Int("pid", cmd.Process.Pid))
+	}
+	return attrs
+}
+
+// logCmdStarted logs that cmd has started, if a structured logger is set.
+func (tf *Terraform) logCmdStarted(ctx context.Context, cmd *exec.Cmd) {
+	if tf.slogger == nil {
+		return
+	}
+	tf.slogger.LogAttrs(ctx, slog.LevelInfo, "Terraform command started", cmdAttrs(cmd)...)
+}
+
+// logCmdFinished logs that cmd, which was run at the given time, has finished
+// with err, if a structured logger is set.
+func (tf *Terraform) logCmdFinished(ctx context.Context, cmd *exec.Cmd, start time.Time, err error) {
+	if tf.slogger == nil {
+		return
+	}
+
+	exitCode := -1
+	if cmd.ProcessState != nil {
+		exitCode = cmd.ProcessState.ExitCode()
+	}
+
+	attrs := append(cmdAttrs(cmd),
+		slog.Duration("duration", time.Since(start)),
+		slog.Int("exit_code", exitCode),
+	)
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	tf.slogger.LogAttrs(ctx, slog.LevelInfo, "Terraform command finished", attrs...)
+}
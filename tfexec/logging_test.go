@@ -0,0 +1,58 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"testing"
+)
+
+func TestLogf(t *testing.T) {
+	var buf bytes.Buffer
+	tf := &Terraform{
+		logger: log.New(&buf, "", 0),
+	}
+
+	attrs := []slog.Attr{slog.Int("attempt", 2)}
+	tf.logf(context.Background(), slog.LevelWarn, attrs, "retrying (attempt %d)", 2)
+	if got := buf.String(); got != "[WARN] retrying (attempt 2)\n" {
+		t.Fatalf("unexpected printfer output: %q", got)
+	}
+
+	buf.Reset()
+	var slogBuf bytes.Buffer
+	tf.SetSlogLogger(slog.New(slog.NewJSONHandler(&slogBuf, nil)))
+	tf.logf(context.Background(), slog.LevelWarn, attrs, "retrying (attempt %d)", 2)
+	if buf.Len() != 0 {
+		t.Fatalf("expected nothing logged to printfer, got %q", buf.String())
+	}
+
+	var record map[string]interface{}
+	err := json.Unmarshal(slogBuf.Bytes(), &record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record["level"] != "WARN" || record["msg"] != "retrying (attempt 2)" || record["attempt"] != 2.0 {
+		t.Fatalf("unexpected record: %v", record)
+	}
+}
+
+func TestLevelPrefix(t *testing.T) {
+	for level, expected := range map[slog.Level]string{
+		slog.LevelDebug:     "DEBUG",
+		slog.LevelInfo:      "INFO",
+		slog.LevelInfo + 1:  "INFO",
+		slog.LevelWarn:      "WARN",
+		slog.LevelError:     "ERROR",
+		slog.LevelError + 4: "ERROR",
+	} {
+		if actual := levelPrefix(level); actual != expected {
+			t.Errorf("expected %s for level %s, got %s", expected, level, actual)
+		}
+	}
+}
@@ -39,6 +39,17 @@ func AllowMissing(allowMissing bool) *AllowMissingOption {
 	return &AllowMissingOption{allowMissing}
 }
 
+// ApplyImportsOption represents whether imports are applied after planning.
+type ApplyImportsOption struct {
+	apply bool
+}
+
+// ApplyImports represents whether the plan importing resources is applied.
+// Defaults to false.
+func ApplyImports(apply bool) *ApplyImportsOption {
+	return &ApplyImportsOption{apply}
+}
+
 // BackendOption represents the -backend flag.
 type BackendOption struct {
 	backend bool
@@ -201,6 +212,17 @@ func GenerateConfigOut(path string) *GenerateConfigOutOption {
 	return &GenerateConfigOutOption{path}
 }
 
+// GuardOption represents a ChangeGuard checked before applying changes.
+type GuardOption struct {
+	guard *ChangeGuard
+}
+
+// Guard represents a ChangeGuard checked before applying changes, taking
+// precedence over the guard set via SetGuard.
+func Guard(guard *ChangeGuard) *GuardOption {
+	return &GuardOption{guard}
+}
+
 type GetOption struct {
 	get bool
 }
@@ -217,6 +239,28 @@ func GetPlugins(getPlugins bool) *GetPluginsOption {
 	return &GetPluginsOption{getPlugins}
 }
 
+// IdentityOption represents the resource identity to import by.
+type IdentityOption struct {
+	identity map[string]interface{}
+}
+
+// Identity represents the resource identity to import by, as an alternative to
+// an import ID. Importing by identity requires Terraform 1.12 or later.
+func Identity(identity map[string]interface{}) *IdentityOption {
+	return &IdentityOption{identity}
+}
+
+// LimitOption represents the maximum number of results to collect.
+type LimitOption struct {
+	limit int
+}
+
+// Limit represents the maximum number of results to collect. Zero means no
+// limit.
+func Limit(limit int) *LimitOption {
+	return &LimitOption{limit}
+}
+
 // LockOption represents the -lock flag.
 type LockOption struct {
 	lock bool
@@ -247,6 +291,16 @@ func NetMirror(netMirror string) *NetMirrorOption {
 	return &NetMirrorOption{netMirror}
 }
 
+// OrCreateOption represents the -or-create flag.
+type OrCreateOption struct {
+	orCreate bool
+}
+
+// OrCreate represents the -or-create flag.
+func OrCreate(orCreate bool) *OrCreateOption {
+	return &OrCreateOption{orCreate}
+}
+
 type OutOption struct {
 	path string
 }
@@ -298,6 +352,28 @@ func PluginDir(pluginDir string) *PluginDirOption {
 	return &PluginDirOption{pluginDir}
 }
 
+// PolicyOption represents policies evaluated before applying changes.
+type PolicyOption struct {
+	evaluators []PolicyEvaluator
+}
+
+// Policy represents policies evaluated before applying changes, in addition
+// to those set via SetPolicies.
+func Policy(evaluators ...PolicyEvaluator) *PolicyOption {
+	return &PolicyOption{evaluators}
+}
+
+// PolicyReportOutOption represents a report filled in by policy evaluation.
+type PolicyReportOutOption struct {
+	report *PolicyReport
+}
+
+// PolicyReportOut represents a report which is filled in with the results of
+// policy evaluation before applying changes, whether or not any failed.
+func PolicyReportOut(report *PolicyReport) *PolicyReportOutOption {
+	return &PolicyReportOutOption{report}
+}
+
 type ProviderOption struct {
 	provider string
 }
@@ -381,6 +457,18 @@ func Replace(address string) *ReplaceOption {
 	return &ReplaceOption{address}
 }
 
+// RetryOption represents a RetryPolicy for idempotent commands.
+type RetryOption struct {
+	policy *RetryPolicy
+}
+
+// Retry represents a RetryPolicy for the call, taking precedence over the
+// policy set via SetRetryPolicy. Pass a policy with MaxAttempts set to 1 to
+// disable retries for the call.
+func Retry(policy *RetryPolicy) *RetryOption {
+	return &RetryOption{policy}
+}
+
 type StateOption struct {
 	path string
 }
@@ -420,11 +508,14 @@ func TestsDirectory(testsDirectory string) *TestsDirectoryOption {
 	return &TestsDirectoryOption{testsDirectory}
 }
 
+// GraphTypeOption represents the -type flag of terraform graph.
 type GraphTypeOption struct {
-	graphType string
+	graphType GraphTypeName
 }
 
-func GraphType(graphType string) *GraphTypeOption {
+// GraphType represents the -type flag of terraform graph. The type is
+// validated against the Terraform version, see GraphTypeName.
+func GraphType(graphType GraphTypeName) *GraphTypeOption {
 	return &GraphTypeOption{graphType}
 }
 
@@ -10,8 +10,9 @@ import (
 )
 
 type outputConfig struct {
-	state string
-	json  bool
+	retryPolicy *RetryPolicy
+	state       string
+	json        bool
 }
 
 var defaultOutputOptions = outputConfig{}
@@ -21,6 +22,10 @@ type OutputOption interface {
 	configureOutput(*outputConfig)
 }
 
+func (opt *RetryOption) configureOutput(conf *outputConfig) {
+	conf.retryPolicy = opt.policy
+}
+
 func (opt *StateOption) configureOutput(conf *outputConfig) {
 	conf.state = opt.path
 }
@@ -37,10 +42,18 @@ type OutputMeta struct {
 
 // Output represents the terraform output subcommand.
 func (tf *Terraform) Output(ctx context.Context, opts ...OutputOption) (map[string]OutputMeta, error) {
-	outputCmd := tf.outputCmd(ctx, opts...)
+	c := defaultOutputOptions
+	for _, o := range opts {
+		o.configureOutput(&c)
+	}
+
+	var outputs map[string]OutputMeta
+	err := tf.withRetry(ctx, c.retryPolicy, "output", func() error {
+		outputCmd := tf.outputCmd(ctx, opts...)
 
-	outputs := map[string]OutputMeta{}
-	err := tf.runTerraformCmdJSON(ctx, outputCmd, &outputs)
+		outputs = map[string]OutputMeta{}
+		return tf.runTerraformCmdJSON(ctx, outputCmd, &outputs)
+	})
 	if err != nil {
 		return nil, err
 	}
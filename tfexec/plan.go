@@ -5,9 +5,12 @@ package tfexec
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 )
 
@@ -24,6 +27,7 @@ type planConfig struct {
 	refresh           bool
 	refreshOnly       bool
 	replaceAddrs      []string
+	retryPolicy       *RetryPolicy
 	state             string
 	targets           []string
 	vars              []string
@@ -79,6 +83,10 @@ func (opt *ReplaceOption) configurePlan(conf *planConfig) {
 	conf.replaceAddrs = append(conf.replaceAddrs, opt.address)
 }
 
+func (opt *RetryOption) configurePlan(conf *planConfig) {
+	conf.retryPolicy = opt.policy
+}
+
 func (opt *ParallelismOption) configurePlan(conf *planConfig) {
 	conf.parallelism = opt.parallelism
 }
@@ -116,15 +124,46 @@ func (opt *GenerateConfigOutOption) configurePlan(conf *planConfig) {
 // The returned error is nil if `terraform plan` has been executed and exits
 // with either 0 or 2.
 func (tf *Terraform) Plan(ctx context.Context, opts ...PlanOption) (bool, error) {
-	cmd, err := tf.planCmd(ctx, opts...)
-	if err != nil {
-		return false, err
+	c := defaultPlanOptions
+	for _, o := range opts {
+		o.configurePlan(&c)
 	}
-	err = tf.runTerraformCmd(ctx, cmd)
-	if err != nil && cmd.ProcessState.ExitCode() == 2 {
-		return true, nil
+
+	// Terraform refuses to overwrite the -generate-config-out file, so any
+	// file written by a failed attempt is removed before retrying
+	generatePath := c.generateConfigOut
+	if generatePath != "" {
+		if !filepath.IsAbs(generatePath) {
+			generatePath = filepath.Join(tf.workingDir, generatePath)
+		}
+		if _, err := os.Lstat(generatePath); err == nil {
+			generatePath = ""
+		}
 	}
-	return false, err
+
+	var hasChanges bool
+	attempt := 0
+	err := tf.withRetry(ctx, c.retryPolicy, "plan", func() error {
+		attempt++
+		if attempt > 1 && generatePath != "" {
+			err := os.Remove(generatePath)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+
+		cmd, err := tf.planCmd(ctx, opts...)
+		if err != nil {
+			return err
+		}
+		err = tf.runTerraformCmd(ctx, cmd)
+		if err != nil && cmd.ProcessState.ExitCode() == 2 {
+			hasChanges = true
+			return nil
+		}
+		return err
+	})
+	return hasChanges, err
 }
 
 // PlanJSON executes `terraform plan` with the specified options as well as the
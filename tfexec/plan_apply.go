@@ -0,0 +1,125 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// PlanAndApply runs `terraform plan` into a temporary plan file, passes the
+// plan to approve and, if approved, applies exactly that plan file.
+//
+// Any Out option in planOpts and DirOrPlan option in applyOpts is
+// overridden. A nil approve func approves every plan. The returned boolean is
+// true if the plan was approved and applied.
+//
+// A checksum of the plan file is recorded once planning completes, and
+// ErrPlanFileChanged is returned without applying if the file was modified
+// before it could be applied. The plan applied is a private copy of the plan
+// file, made when verifying the checksum.
+func (tf *Terraform) PlanAndApply(ctx context.Context, planOpts []PlanOption, applyOpts []ApplyOption, approve func(*tfjson.Plan) bool) (bool, error) {
+	dir, err := os.MkdirTemp("", "tfexec-plan")
+	if err != nil {
+		return false, err
+	}
+	defer os.RemoveAll(dir)
+
+	planPath := filepath.Join(dir, "tfplan")
+
+	planOpts = append(planOpts[:len(planOpts):len(planOpts)], Out(planPath))
+	_, err = tf.Plan(ctx, planOpts...)
+	if err != nil {
+		return false, err
+	}
+
+	checksum, err := fileChecksum(planPath)
+	if err != nil {
+		return false, err
+	}
+
+	plan, err := tf.ShowPlanFile(ctx, planPath)
+	if err != nil {
+		return false, err
+	}
+
+	if approve != nil && !approve(plan) {
+		return false, nil
+	}
+
+	// the plan file could still change between verifying and applying it, so
+	// apply a private copy which is verified instead
+	applyPath, actual, err := copyToTemp(planPath, "tfexec-apply-*.tfplan")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(applyPath)
+
+	if actual != checksum {
+		return false, &ErrPlanFileChanged{
+			Path:     planPath,
+			Expected: checksum,
+			Actual:   actual,
+		}
+	}
+
+	applyOpts = append(applyOpts[:len(applyOpts):len(applyOpts)], DirOrPlan(applyPath))
+	err = tf.Apply(ctx, applyOpts...)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// fileChecksum returns the hex encoded SHA-256 checksum of a file.
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// copyToTemp copies a file to a new temporary file only readable by the
+// current user, created with the given name pattern, and returns its path and
+// the hex encoded SHA-256 checksum of the copied content.
+func copyToTemp(path string, pattern string) (string, string, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return "", "", err
+	}
+	defer src.Close()
+
+	dst, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", "", err
+	}
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(dst, h), src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(dst.Name())
+		return "", "", err
+	}
+
+	return dst.Name(), hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,50 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCopyToTemp(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tfplan")
+	err := os.WriteFile(path, []byte("plan"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	copyPath, checksum, err := copyToTemp(path, "tfexec-test-*.tfplan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(copyPath)
+
+	if copyPath == path {
+		t.Fatal("expected a copy of the file")
+	}
+
+	// changing the original does not affect the copy
+	err = os.WriteFile(path, []byte("changed"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(copyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "plan" {
+		t.Fatalf("expected copied content %q, got %q", "plan", b)
+	}
+
+	expected, err := fileChecksum(copyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checksum != expected {
+		t.Fatalf("expected checksum %s, got %s", expected, checksum)
+	}
+}
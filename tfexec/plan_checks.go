@@ -0,0 +1,247 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// planChecks are the checks performed on a plan before it is applied.
+type planChecks struct {
+	guard    *ChangeGuard
+	policies []PolicyEvaluator
+	report   *PolicyReport
+}
+
+func (tf *Terraform) planChecks(c applyConfig) planChecks {
+	checks := planChecks{
+		guard:  c.guard,
+		report: c.policyReport,
+	}
+	if checks.guard == nil {
+		checks.guard = tf.guard
+	}
+	checks.policies = append(checks.policies, tf.policies...)
+	checks.policies = append(checks.policies, c.policies...)
+	return checks
+}
+
+func (p planChecks) empty() bool {
+	return p.guard == nil && len(p.policies) == 0
+}
+
+// checkedApplyOptions checks the plan an Apply call with the given options
+// would apply against the effective guard and policies, if any. When no saved
+// plan is given, a plan is created and the returned options apply that plan
+// instead. The returned func removes any temporary plan and must always be
+// called.
+func (tf *Terraform) checkedApplyOptions(ctx context.Context, opts []ApplyOption) ([]ApplyOption, func(), error) {
+	cleanup := func() {}
+
+	c := defaultApplyOptions
+	for _, o := range opts {
+		o.configureApply(&c)
+	}
+
+	checks := tf.planChecks(c)
+	if checks.empty() {
+		return opts, cleanup, nil
+	}
+
+	if c.dirOrPlan != "" {
+		path := c.dirOrPlan
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(tf.workingDir, path)
+		}
+		info, err := os.Stat(path)
+		if err == nil && !info.IsDir() {
+			return opts, cleanup, tf.checkPlan(ctx, checks, c.dirOrPlan, c)
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "tfexec-plan")
+	if err != nil {
+		return nil, cleanup, err
+	}
+	cleanup = func() {
+		os.RemoveAll(dir)
+	}
+
+	planPath := filepath.Join(dir, "tfplan")
+
+	planOpts := []PlanOption{
+		Out(planPath),
+		Destroy(c.destroy),
+		Lock(c.lock),
+		Parallelism(c.parallelism),
+		Refresh(c.refresh),
+		RefreshOnly(c.refreshOnly),
+		AllowDeferral(c.allowDeferral),
+	}
+	if c.dirOrPlan != "" {
+		planOpts = append(planOpts, Dir(c.dirOrPlan))
+	}
+	if c.lockTimeout != "" {
+		planOpts = append(planOpts, LockTimeout(c.lockTimeout))
+	}
+	if c.reattachInfo != nil {
+		planOpts = append(planOpts, Reattach(c.reattachInfo))
+	}
+	if c.state != "" {
+		planOpts = append(planOpts, State(c.state))
+	}
+	for _, addr := range c.replaceAddrs {
+		planOpts = append(planOpts, Replace(addr))
+	}
+	for _, target := range c.targets {
+		planOpts = append(planOpts, Target(target))
+	}
+	for _, v := range c.vars {
+		planOpts = append(planOpts, Var(v))
+	}
+	for _, vf := range c.varFiles {
+		planOpts = append(planOpts, VarFile(vf))
+	}
+
+	_, err = tf.Plan(ctx, planOpts...)
+	if err != nil {
+		return nil, cleanup, err
+	}
+
+	err = tf.checkPlan(ctx, checks, planPath, c)
+	if err != nil {
+		return nil, cleanup, err
+	}
+
+	// only options which are valid when applying a saved plan
+	applyOpts := []ApplyOption{
+		DirOrPlan(planPath),
+		Lock(c.lock),
+		Parallelism(c.parallelism),
+	}
+	if c.backup != "" {
+		applyOpts = append(applyOpts, Backup(c.backup))
+	}
+	if c.lockTimeout != "" {
+		applyOpts = append(applyOpts, LockTimeout(c.lockTimeout))
+	}
+	if c.reattachInfo != nil {
+		applyOpts = append(applyOpts, Reattach(c.reattachInfo))
+	}
+	if c.state != "" {
+		applyOpts = append(applyOpts, State(c.state))
+	}
+	if c.stateOut != "" {
+		applyOpts = append(applyOpts, StateOut(c.stateOut))
+	}
+
+	return applyOpts, cleanup, nil
+}
+
+// destroyCheckedApplyOptions returns the options to apply an equivalent
+// destroy plan if the given Destroy options, or the instance, carry a guard
+// or policies.
+func (tf *Terraform) destroyCheckedApplyOptions(opts []DestroyOption) ([]ApplyOption, bool) {
+	c := defaultDestroyOptions
+	for _, o := range opts {
+		o.configureDestroy(&c)
+	}
+
+	if c.guard == nil && tf.guard == nil && len(c.policies) == 0 && len(tf.policies) == 0 {
+		return nil, false
+	}
+
+	applyOpts := []ApplyOption{
+		Destroy(true),
+		Lock(c.lock),
+		Parallelism(c.parallelism),
+		Refresh(c.refresh),
+	}
+	if c.guard != nil {
+		applyOpts = append(applyOpts, Guard(c.guard))
+	}
+	if len(c.policies) > 0 {
+		applyOpts = append(applyOpts, Policy(c.policies...))
+	}
+	if c.policyReport != nil {
+		applyOpts = append(applyOpts, PolicyReportOut(c.policyReport))
+	}
+	if c.backup != "" {
+		applyOpts = append(applyOpts, Backup(c.backup))
+	}
+	if c.dir != "" {
+		applyOpts = append(applyOpts, DirOrPlan(c.dir))
+	}
+	if c.lockTimeout != "" {
+		applyOpts = append(applyOpts, LockTimeout(c.lockTimeout))
+	}
+	if c.reattachInfo != nil {
+		applyOpts = append(applyOpts, Reattach(c.reattachInfo))
+	}
+	if c.state != "" {
+		applyOpts = append(applyOpts, State(c.state))
+	}
+	if c.stateOut != "" {
+		applyOpts = append(applyOpts, StateOut(c.stateOut))
+	}
+	for _, target := range c.targets {
+		applyOpts = append(applyOpts, Target(target))
+	}
+	for _, v := range c.vars {
+		applyOpts = append(applyOpts, Var(v))
+	}
+	for _, vf := range c.varFiles {
+		applyOpts = append(applyOpts, VarFile(vf))
+	}
+
+	return applyOpts, true
+}
+
+// checkPlan checks a saved plan against the guard first and then evaluates
+// the policies, filling in the report if one was requested.
+func (tf *Terraform) checkPlan(ctx context.Context, checks planChecks, planPath string, c applyConfig) error {
+	var showOpts []ShowOption
+	if c.reattachInfo != nil {
+		showOpts = append(showOpts, Reattach(c.reattachInfo))
+	}
+
+	plan, err := tf.ShowPlanFile(ctx, planPath, showOpts...)
+	if err != nil {
+		return err
+	}
+
+	if checks.guard != nil {
+		err = checks.guard.Check(plan)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(checks.policies) == 0 {
+		return nil
+	}
+
+	report, err := tf.evaluatePolicies(ctx, checks.policies, plan, PolicyMetadata{
+		PlanFile: planPath,
+		Destroy:  c.destroy,
+		Targets:  c.targets,
+	})
+	if err != nil {
+		return err
+	}
+
+	if checks.report != nil {
+		*checks.report = *report
+	}
+
+	if report.Failed() {
+		return &ErrPolicyFailed{
+			Report: report,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// PolicyStatus is the outcome of a policy evaluation.
+type PolicyStatus string
+
+const (
+	PolicyPass PolicyStatus = "pass"
+	PolicyWarn PolicyStatus = "warn"
+	PolicyFail PolicyStatus = "fail"
+)
+
+// PolicyInput is passed to a PolicyEvaluator.
+type PolicyInput struct {
+	// Plan is the plan about to be applied.
+	Plan *tfjson.Plan
+
+	// PriorState is the state the plan was created from, which may be nil
+	// for a workspace without state.
+	PriorState *tfjson.State
+
+	Metadata PolicyMetadata
+}
+
+// PolicyMetadata describes the run a plan is evaluated for.
+type PolicyMetadata struct {
+	WorkingDir       string
+	Workspace        string
+	TerraformVersion string
+
+	// PlanFile is the path of the saved plan being evaluated.
+	PlanFile string
+
+	// Destroy is true if the plan was created in destroy mode.
+	Destroy bool
+
+	Targets []string
+}
+
+// PolicyResult is a single result returned by a PolicyEvaluator.
+type PolicyResult struct {
+	// Policy is the name of the policy the result belongs to.
+	Policy  string
+	Status  PolicyStatus
+	Message string
+}
+
+// PolicyEvaluator evaluates a plan before it is applied. An evaluator may
+// return any number of results. A returned error aborts the evaluation and is
+// distinct from a failing result.
+type PolicyEvaluator interface {
+	Evaluate(ctx context.Context, input *PolicyInput) ([]PolicyResult, error)
+}
+
+// PolicyFunc is an adapter to allow the use of ordinary functions as a
+// PolicyEvaluator.
+type PolicyFunc func(ctx context.Context, input *PolicyInput) ([]PolicyResult, error)
+
+// Evaluate calls f(ctx, input).
+func (f PolicyFunc) Evaluate(ctx context.Context, input *PolicyInput) ([]PolicyResult, error) {
+	return f(ctx, input)
+}
+
+// PolicyReport collects the results of all evaluated policies.
+type PolicyReport struct {
+	Metadata PolicyMetadata
+	Results  []PolicyResult
+}
+
+// Failed returns true if any result has failed.
+func (r *PolicyReport) Failed() bool {
+	return len(r.withStatus(PolicyFail)) > 0
+}
+
+// Failures returns the failed results.
+func (r *PolicyReport) Failures() []PolicyResult {
+	return r.withStatus(PolicyFail)
+}
+
+// Warnings returns the results which passed with a warning.
+func (r *PolicyReport) Warnings() []PolicyResult {
+	return r.withStatus(PolicyWarn)
+}
+
+func (r *PolicyReport) withStatus(status PolicyStatus) []PolicyResult {
+	var results []PolicyResult
+	for _, res := range r.Results {
+		if res.Status == status {
+			results = append(results, res)
+		}
+	}
+	return results
+}
+
+// SetPolicies sets the policies evaluated before every Apply and Destroy call
+// of this instance, in addition to those passed via the Policy option. Pass
+// no evaluators to remove them.
+//
+// Like a ChangeGuard, policies cause applies to go through a saved plan. If
+// any result fails, ErrPolicyFailed is returned without applying anything.
+func (tf *Terraform) SetPolicies(evaluators ...PolicyEvaluator) {
+	tf.policies = evaluators
+}
+
+// EvaluatePolicies evaluates the given policies against a saved plan file and
+// returns the report. Unlike Apply, it does not return ErrPolicyFailed for
+// failed results; use PolicyReport.Failed to check the outcome.
+func (tf *Terraform) EvaluatePolicies(ctx context.Context, planPath string, evaluators ...PolicyEvaluator) (*PolicyReport, error) {
+	plan, err := tf.ShowPlanFile(ctx, planPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return tf.evaluatePolicies(ctx, evaluators, plan, PolicyMetadata{
+		PlanFile: planPath,
+	})
+}
+
+// evaluatePolicies runs each evaluator in order, completing the metadata with
+// the details of the plan and the current workspace.
+func (tf *Terraform) evaluatePolicies(ctx context.Context, evaluators []PolicyEvaluator, plan *tfjson.Plan, meta PolicyMetadata) (*PolicyReport, error) {
+	meta.WorkingDir = tf.workingDir
+	meta.TerraformVersion = plan.TerraformVersion
+
+	meta.Workspace = tf.workspace
+	if meta.Workspace == "" {
+		ws, err := tf.WorkspaceShow(ctx)
+		if err != nil {
+			return nil, err
+		}
+		meta.Workspace = ws
+	}
+
+	input := &PolicyInput{
+		Plan:       plan,
+		PriorState: plan.PriorState,
+		Metadata:   meta,
+	}
+
+	report := &PolicyReport{
+		Metadata: meta,
+	}
+	for _, e := range evaluators {
+		results, err := e.Evaluate(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating policy: %w", err)
+		}
+		report.Results = append(report.Results, results...)
+	}
+
+	return report, nil
+}
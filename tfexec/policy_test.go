@@ -0,0 +1,47 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPolicyReport(t *testing.T) {
+	report := &PolicyReport{
+		Results: []PolicyResult{
+			{Policy: "tags", Status: PolicyPass},
+			{Policy: "regions", Status: PolicyWarn, Message: "us-east-1 is deprecated"},
+			{Policy: "public-buckets", Status: PolicyFail, Message: "aws_s3_bucket.logs is public"},
+		},
+	}
+
+	if !report.Failed() {
+		t.Fatal("expected report to have failed")
+	}
+
+	if diff := cmp.Diff(report.Results[2:], report.Failures()); diff != "" {
+		t.Fatalf("unexpected failures: %s", diff)
+	}
+	if diff := cmp.Diff(report.Results[1:2], report.Warnings()); diff != "" {
+		t.Fatalf("unexpected warnings: %s", diff)
+	}
+
+	var err error = &ErrPolicyFailed{Report: report}
+	var e *ErrPolicyFailed
+	if !errors.As(err, &e) {
+		t.Fatalf("expected ErrPolicyFailed, got %T", err)
+	}
+	expected := "refusing to apply plan failing 1 policy checks: public-buckets: aws_s3_bucket.logs is public"
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err.Error())
+	}
+
+	passed := &PolicyReport{Results: report.Results[:2]}
+	if passed.Failed() {
+		t.Fatal("expected report to have passed")
+	}
+}
@@ -13,6 +13,7 @@ import (
 type queryConfig struct {
 	dir            string
 	generateConfig string
+	limit          int
 	reattachInfo   ReattachInfo
 	vars           []string
 	varFiles       []string
@@ -33,6 +34,10 @@ func (opt *GenerateConfigOutOption) configureQuery(conf *queryConfig) {
 	conf.generateConfig = opt.path
 }
 
+func (opt *LimitOption) configureQuery(conf *queryConfig) {
+	conf.limit = opt.limit
+}
+
 func (opt *ReattachOption) configureQuery(conf *queryConfig) {
 	conf.reattachInfo = opt.info
 }
@@ -0,0 +1,160 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// QueryResult is the result of Query.
+type QueryResult struct {
+	// Resources lists the resources found by all list blocks, in the order
+	// they were reported. ResourceObject is only set for list blocks with
+	// include_resource enabled.
+	Resources []tfjson.ListResourceFoundData
+
+	// Lists summarizes each completed list block.
+	Lists []tfjson.ListCompleteData
+
+	// Truncated is true if the query was stopped early because the Limit was
+	// reached.
+	Truncated bool
+
+	// Diagnostics lists all warnings and errors reported by Terraform.
+	Diagnostics []tfjson.Diagnostic
+
+	// GeneratedConfig lists the blocks written to the GenerateConfigOut path,
+	// if one was given. It is not set if the query was Truncated, as the file
+	// may not have been written completely.
+	GeneratedConfig []GeneratedBlock
+}
+
+// GeneratedBlock is a top-level block of configuration generated by
+// Terraform, e.g. a resource or import block.
+type GeneratedBlock struct {
+	Type   string
+	Labels []string
+
+	// Source is the formatted source of the block, including any leading
+	// comments.
+	Source string
+}
+
+// Query runs `terraform query` with the `-json` flag and collects the
+// resources found by list blocks, as introduced in Terraform 1.14.
+//
+// With the Limit option, the command is stopped once that many resources
+// have been found, and no generated configuration is returned.
+//
+// An error is returned if the command fails, including the summaries of any
+// error diagnostics.
+func (tf *Terraform) Query(ctx context.Context, opts ...QueryOption) (*QueryResult, error) {
+	c := defaultQueryOptions
+	for _, o := range opts {
+		o.configureQuery(&c)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	seq, err := tf.QueryJSON(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &QueryResult{}
+	var cmdErr, decodeErr error
+	for next := range seq {
+		if result.Truncated || decodeErr != nil {
+			// drain remaining output until the command has exited
+			continue
+		}
+		if next.Msg == nil {
+			cmdErr = next.Err
+			continue
+		}
+		if next.Err != nil {
+			decodeErr = next.Err
+			cancel()
+			continue
+		}
+
+		switch msg := next.Msg.(type) {
+		case tfjson.ListResourceFoundMessage:
+			result.Resources = append(result.Resources, msg.ListResourceFound)
+			if c.limit > 0 && len(result.Resources) >= c.limit {
+				result.Truncated = true
+				cancel()
+			}
+		case tfjson.ListCompleteMessage:
+			result.Lists = append(result.Lists, msg.ListComplete)
+		case tfjson.DiagnosticLogMessage:
+			result.Diagnostics = append(result.Diagnostics, msg.Diagnostic)
+		}
+	}
+
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	if cmdErr != nil && !result.Truncated {
+		var summaries []string
+		for _, d := range result.Diagnostics {
+			if d.Severity == tfjson.DiagnosticSeverityError {
+				summaries = append(summaries, d.Summary)
+			}
+		}
+		if len(summaries) > 0 {
+			return nil, fmt.Errorf("%w: %s", cmdErr, strings.Join(summaries, "; "))
+		}
+		return nil, cmdErr
+	}
+
+	if c.generateConfig != "" && !result.Truncated {
+		path := c.generateConfig
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(tf.workingDir, path)
+		}
+		src, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		result.GeneratedConfig, err = ParseGeneratedConfig(src)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// ParseGeneratedConfig parses configuration generated via the
+// -generate-config-out flag of `terraform plan` or `terraform query` into its
+// top-level blocks.
+func ParseGeneratedConfig(src []byte) ([]GeneratedBlock, error) {
+	f, diags := hclwrite.ParseConfig(src, "generated.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	var blocks []GeneratedBlock
+	for _, b := range f.Body().Blocks() {
+		blocks = append(blocks, GeneratedBlock{
+			Type:   b.Type(),
+			Labels: b.Labels(),
+			Source: strings.TrimSpace(string(b.BuildTokens(nil).Bytes())),
+		})
+	}
+
+	return blocks, nil
+}
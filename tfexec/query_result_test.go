@@ -0,0 +1,55 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseGeneratedConfig(t *testing.T) {
+	src := `# __generated__ by Terraform
+# Please review these resources and move them into your main configuration files.
+
+# __generated__ by Terraform from "pet-1"
+resource "concept_pet" "pet_1" {
+  name = "rex"
+}
+
+import {
+  to       = concept_pet.pet_1
+  identity = {
+    id = "pet-1"
+  }
+}
+`
+
+	blocks, err := ParseGeneratedConfig([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []GeneratedBlock{
+		{
+			Type:   "resource",
+			Labels: []string{"concept_pet", "pet_1"},
+			Source: "# __generated__ by Terraform from \"pet-1\"\nresource \"concept_pet\" \"pet_1\" {\n  name = \"rex\"\n}",
+		},
+		{
+			Type:   "import",
+			Labels: []string{},
+			Source: "import {\n  to       = concept_pet.pet_1\n  identity = {\n    id = \"pet-1\"\n  }\n}",
+		},
+	}
+
+	if diff := cmp.Diff(expected, blocks); diff != "" {
+		t.Fatalf("unexpected blocks: %s", diff)
+	}
+
+	_, err = ParseGeneratedConfig([]byte(`resource "a" {`))
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
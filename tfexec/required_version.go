@@ -0,0 +1,140 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var (
+	terraformBlockSchema = &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "terraform"},
+		},
+	}
+
+	requiredVersionSchema = &hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "required_version"},
+		},
+	}
+)
+
+// requiredVersion is a required_version constraint declared in a terraform
+// block of a configuration file.
+type requiredVersion struct {
+	filename    string
+	constraints version.Constraints
+}
+
+// CheckRequiredVersion checks the required_version constraints declared in the
+// working directory's configuration against the version of the Terraform
+// executable, without running any other command.
+//
+// ErrRequiredVersionMismatch is returned for the first constraint which is not
+// satisfied.
+func (tf *Terraform) CheckRequiredVersion(ctx context.Context) error {
+	tfv, _, err := tf.Version(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	required, err := requiredVersions(tf.workingDir)
+	if err != nil {
+		return err
+	}
+
+	tfv = stripPrereleaseAndMeta(tfv)
+	for _, rv := range required {
+		if !rv.constraints.Check(tfv) {
+			return &ErrRequiredVersionMismatch{
+				Filename:   rv.filename,
+				Constraint: rv.constraints.String(),
+				Actual:     tfv.String(),
+			}
+		}
+	}
+
+	return nil
+}
+
+// requiredVersions returns the required_version constraints declared in the
+// Terraform configuration files (.tf and .tf.json) of dir.
+func requiredVersions(dir string) ([]requiredVersion, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := hclparse.NewParser()
+	var required []requiredVersion
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		filename := filepath.Join(dir, e.Name())
+
+		var file *hcl.File
+		var diags hcl.Diagnostics
+		switch {
+		case strings.HasSuffix(e.Name(), ".tf"):
+			file, diags = parser.ParseHCLFile(filename)
+		case strings.HasSuffix(e.Name(), ".tf.json"):
+			file, diags = parser.ParseJSONFile(filename)
+		default:
+			continue
+		}
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		content, _, diags := file.Body.PartialContent(terraformBlockSchema)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		for _, block := range content.Blocks {
+			attrs, _, diags := block.Body.PartialContent(requiredVersionSchema)
+			if diags.HasErrors() {
+				return nil, diags
+			}
+
+			attr, ok := attrs.Attributes["required_version"]
+			if !ok {
+				continue
+			}
+
+			val, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				return nil, diags
+			}
+			if val.IsNull() || !val.Type().Equals(cty.String) {
+				return nil, fmt.Errorf("%s: required_version must be a string", attr.Range)
+			}
+
+			constraints, err := version.NewConstraint(val.AsString())
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid required_version %q: %w", attr.Range, val.AsString(), err)
+			}
+
+			required = append(required, requiredVersion{
+				filename:    filename,
+				constraints: constraints,
+			})
+		}
+	}
+
+	return required, nil
+}
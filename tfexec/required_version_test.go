@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-exec/tfexec/internal/testutil"
+)
+
+func TestCheckRequiredVersion(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		files    map[string]string
+		mismatch string
+	}{
+		{
+			"no constraints",
+			map[string]string{
+				"main.tf": `resource "null_resource" "foo" {}`,
+			},
+			"",
+		},
+		{
+			"satisfied",
+			map[string]string{
+				"main.tf":     `resource "null_resource" "foo" {}`,
+				"versions.tf": `terraform { required_version = ">= 1.6, < 2.0" }`,
+			},
+			"",
+		},
+		{
+			"not satisfied",
+			map[string]string{
+				"main.tf":     `terraform { required_version = ">= 1.6" }`,
+				"versions.tf": `terraform { required_version = "~> 1.5.0" }`,
+			},
+			"versions.tf",
+		},
+		{
+			"json",
+			map[string]string{
+				"main.tf.json": `{"terraform": {"required_version": "< 1.0"}}`,
+			},
+			"main.tf.json",
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			td := t.TempDir()
+			for name, content := range c.files {
+				err := os.WriteFile(filepath.Join(td, name), []byte(content), 0o644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			tf, err := NewTerraform(td, tfVersion(t, testutil.Latest_v1))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = tf.CheckRequiredVersion(context.Background())
+			if c.mismatch == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %s", err)
+				}
+				return
+			}
+
+			var e *ErrRequiredVersionMismatch
+			if !errors.As(err, &e) {
+				t.Fatalf("expected ErrRequiredVersionMismatch, got %T %s", err, err)
+			}
+			if e.Filename != filepath.Join(td, c.mismatch) {
+				t.Fatalf("expected mismatch in %q, got %q", c.mismatch, e.Filename)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"regexp"
+	"time"
+)
+
+// RetryReason classifies a transient failure, see ClassifyRetryable.
+type RetryReason string
+
+const (
+	// RetryNone means the failure is not considered transient.
+	RetryNone RetryReason = ""
+
+	// RetryStateLock means the state lock is held by another operation.
+	RetryStateLock RetryReason = "state lock contention"
+
+	// RetryDownload means a registry, provider or module download failed
+	// with a timeout or connection error.
+	RetryDownload RetryReason = "download failure"
+
+	// RetryPluginStartup means a provider plugin failed to start.
+	RetryPluginStartup RetryReason = "plugin startup failure"
+
+	// RetryBackend means the state backend responded with a 5xx error.
+	RetryBackend RetryReason = "backend server error"
+)
+
+var retryPatterns = []struct {
+	reason RetryReason
+	re     *regexp.Regexp
+}{
+	{RetryStateLock, regexp.MustCompile(`Error acquiring the state lock|Error locking state`)},
+	{RetryPluginStartup, regexp.MustCompile(`plugin exited before we could connect|timeout while waiting for plugin to start|Unrecognized remote plugin message|Failed to load plugin schemas|failed to instantiate provider`)},
+	{RetryBackend, regexp.MustCompile(`(?i)status(?:[ _]?code)?:? ?5\d\d\b|\b5\d\d (?:Internal Server Error|Bad Gateway|Service Unavailable|Gateway Timeout)`)},
+	{RetryDownload, regexp.MustCompile(`(?i)Client\.Timeout exceeded|TLS handshake timeout|i/o timeout|connection reset by peer|connection refused`)},
+}
+
+// ClassifyRetryable returns the reason the given error of a Terraform
+// command is considered transient, or RetryNone. It is the default
+// classification of a RetryPolicy.
+//
+// Errors caused by the cancellation of the command's context are never
+// transient.
+func ClassifyRetryable(err error) RetryReason {
+	if err == nil {
+		return RetryNone
+	}
+	var ce cmdErr
+	if errors.As(err, &ce) && ce.ctxErr != nil {
+		return RetryNone
+	}
+	var lockErr *ErrStateLocked
+	if errors.As(err, &lockErr) {
+		return RetryStateLock
+	}
+
+	msg := err.Error()
+	for _, p := range retryPatterns {
+		if p.re.MatchString(msg) {
+			return p.reason
+		}
+	}
+	return RetryNone
+}
+
+// RetryPolicy retries idempotent commands which fail transiently: Init,
+// Plan, Validate, Show, ShowStateFile, ShowPlanFile, ShowPlanFileRaw, Output
+// and StatePull. Other commands are never retried. Validate takes no options,
+// so only the policy set via SetRetryPolicy applies to it.
+//
+// The delay before each retry grows exponentially from InitialBackoff up to
+// MaxBackoff and is randomized by Jitter. Waiting is interrupted if the
+// context is cancelled. Each retry is logged via the logger set with
+// SetLogger.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a command is run, including
+	// the first attempt. Defaults to 3 if zero. A value of 1 disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry, 1s if zero.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between attempts, 30s if zero.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction by which each delay is randomly increased or
+	// decreased, 0.2 if zero. A negative value disables jitter.
+	Jitter float64
+
+	// Classify returns why an error is transient, or RetryNone if the
+	// command should not be retried. Defaults to ClassifyRetryable.
+	Classify func(error) RetryReason
+}
+
+// SetRetryPolicy sets the RetryPolicy used by the idempotent commands of
+// this instance. A Retry option passed to the call takes precedence. Pass
+// nil to disable retries.
+func (tf *Terraform) SetRetryPolicy(policy *RetryPolicy) {
+	tf.retryPolicy = policy
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts == 0 {
+		return 3
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns the delay before the given retry, starting at 1.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	initial := p.InitialBackoff
+	if initial == 0 {
+		initial = time.Second
+	}
+	maxDelay := p.MaxBackoff
+	if maxDelay == 0 {
+		maxDelay = 30 * time.Second
+	}
+	jitter := p.Jitter
+	if jitter == 0 {
+		jitter = 0.2
+	}
+
+	delay := initial
+	for i := 1; i < retry && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	if jitter > 0 {
+		delay = time.Duration(float64(delay) * (1 + jitter*(2*rand.Float64()-1)))
+	}
+	return delay
+}
+
+func (p *RetryPolicy) classify(err error) RetryReason {
+	if p.Classify != nil {
+		return p.Classify(err)
+	}
+	return ClassifyRetryable(err)
+}
+
+// withRetry calls run, which must build and run a fresh command each time,
+// until it succeeds or fails with an error the policy does not consider
+// transient. The policy set on tf is used if policy is nil.
+func (tf *Terraform) withRetry(ctx context.Context, policy *RetryPolicy, command string, run func() error) error {
+	if policy == nil {
+		policy = tf.retryPolicy
+	}
+
+	err := run()
+	if policy == nil {
+		return err
+	}
+
+	maxAttempts := policy.maxAttempts()
+	for attempt := 2; err != nil && attempt <= maxAttempts; attempt++ {
+		reason := policy.classify(err)
+		if reason == RetryNone || ctx.Err() != nil {
+			return err
+		}
+
+		delay := policy.backoff(attempt - 1)
+		tf.logf(ctx, slog.LevelWarn, []slog.Attr{
+			slog.String("command", command),
+			slog.String("reason", string(reason)),
+			slog.Duration("delay", delay),
+			slog.Int("attempt", attempt),
+			slog.Int("max_attempts", maxAttempts),
+		}, "terraform %s failed due to %s, retrying in %s (attempt %d of %d)", command, reason, delay, attempt, maxAttempts)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w\nretry aborted: %w", err, ctx.Err())
+		case <-timer.C:
+		}
+
+		err = run()
+	}
+
+	return err
+}
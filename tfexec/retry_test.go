@@ -0,0 +1,251 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClassifyRetryable(t *testing.T) {
+	for _, c := range []struct {
+		err      error
+		expected RetryReason
+	}{
+		{nil, RetryNone},
+		{errors.New("exit status 1\n\nError: Unsupported argument"), RetryNone},
+		{errors.New("exit status 1\n\nError: Error acquiring the state lock\n\nLock Info:\n  ID: 1234"), RetryStateLock},
+		{errors.New(`exit status 1
+
+Error: Failed to query available provider packages
+
+Could not retrieve the list of available versions for provider hashicorp/null: could not connect to registry.terraform.io: Failed to request discovery document: Get "https://registry.terraform.io/.well-known/terraform.json": net/http: request canceled while waiting for connection (Client.Timeout exceeded while awaiting headers)`), RetryDownload},
+		{errors.New("exit status 1\n\nError: Failed to download module\n\nread tcp 10.0.0.1:5000->10.0.0.2:443: read: connection reset by peer"), RetryDownload},
+		{errors.New("exit status 1\n\nError: Failed to load plugin schemas"), RetryPluginStartup},
+		{errors.New("exit status 1\n\nError: Plugin did not respond\n\nplugin exited before we could connect"), RetryPluginStartup},
+		{errors.New("exit status 1\n\nError: Failed to get existing workspaces: operation error S3: ListObjectsV2, https response error StatusCode: 503, RequestID: 1234"), RetryBackend},
+		{errors.New("exit status 1\n\nError: Error refreshing state: 502 Bad Gateway"), RetryBackend},
+		{cmdErr{err: errors.New("signal: killed i/o timeout"), ctxErr: context.Canceled}, RetryNone},
+	} {
+		actual := ClassifyRetryable(c.err)
+		if actual != c.expected {
+			t.Errorf("expected %q for %v, got %q", c.expected, c.err, actual)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+		Jitter:         -1,
+	}
+	for retry, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		actual := p.backoff(retry + 1)
+		if actual != expected {
+			t.Errorf("retry %d: expected %s, got %s", retry+1, expected, actual)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		actual := p.backoff(2)
+		if actual < time.Second || actual > 3*time.Second {
+			t.Fatalf("expected delay within 50%% of 2s, got %s", actual)
+		}
+	}
+}
+
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestWithRetry(t *testing.T) {
+	lockErr := errors.New("exit status 1\n\nError: Error acquiring the state lock")
+
+	newTF := func(t *testing.T) (*Terraform, *testLogger) {
+		tf, err := NewTerraform(t.TempDir(), "terraform")
+		if err != nil {
+			t.Fatal(err)
+		}
+		logger := &testLogger{}
+		tf.SetLogger(logger)
+		return tf, logger
+	}
+
+	failing := func(errs ...error) (func() error, *int) {
+		attempts := 0
+		return func() error {
+			attempts++
+			if attempts <= len(errs) {
+				return errs[attempts-1]
+			}
+			return nil
+		}, &attempts
+	}
+
+	t.Run("no policy", func(t *testing.T) {
+		tf, _ := newTF(t)
+		run, attempts := failing(lockErr)
+		err := tf.withRetry(context.Background(), nil, "plan", run)
+		if err != lockErr || *attempts != 1 {
+			t.Fatalf("expected a single failed attempt, got %d: %v", *attempts, err)
+		}
+	})
+
+	t.Run("transient", func(t *testing.T) {
+		tf, logger := newTF(t)
+		tf.SetRetryPolicy(&RetryPolicy{InitialBackoff: time.Millisecond})
+		run, attempts := failing(lockErr, lockErr)
+		err := tf.withRetry(context.Background(), nil, "plan", run)
+		if err != nil || *attempts != 3 {
+			t.Fatalf("expected success on attempt 3, got %d: %v", *attempts, err)
+		}
+		if len(logger.lines) != 2 || !strings.HasPrefix(logger.lines[1], "[WARN] terraform plan failed due to state lock contention, retrying in ") || !strings.HasSuffix(logger.lines[1], "(attempt 3 of 3)") {
+			t.Fatalf("unexpected log: %q", logger.lines)
+		}
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		tf, _ := newTF(t)
+		tf.SetRetryPolicy(&RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+		run, attempts := failing(lockErr, lockErr, lockErr)
+		err := tf.withRetry(context.Background(), nil, "plan", run)
+		if err != lockErr || *attempts != 2 {
+			t.Fatalf("expected 2 failed attempts, got %d: %v", *attempts, err)
+		}
+	})
+
+	t.Run("permanent", func(t *testing.T) {
+		tf, _ := newTF(t)
+		tf.SetRetryPolicy(&RetryPolicy{InitialBackoff: time.Millisecond})
+		permanentErr := errors.New("exit status 1\n\nError: Unsupported argument")
+		run, attempts := failing(permanentErr)
+		err := tf.withRetry(context.Background(), nil, "plan", run)
+		if err != permanentErr || *attempts != 1 {
+			t.Fatalf("expected a single failed attempt, got %d: %v", *attempts, err)
+		}
+	})
+
+	t.Run("call policy takes precedence", func(t *testing.T) {
+		tf, _ := newTF(t)
+		tf.SetRetryPolicy(&RetryPolicy{InitialBackoff: time.Millisecond})
+		run, attempts := failing(lockErr)
+		err := tf.withRetry(context.Background(), &RetryPolicy{MaxAttempts: 1}, "plan", run)
+		if err != lockErr || *attempts != 1 {
+			t.Fatalf("expected a single failed attempt, got %d: %v", *attempts, err)
+		}
+	})
+
+	t.Run("custom classification", func(t *testing.T) {
+		tf, _ := newTF(t)
+		customErr := errors.New("flaky")
+		policy := &RetryPolicy{
+			InitialBackoff: time.Millisecond,
+			Classify: func(err error) RetryReason {
+				if err == customErr {
+					return "flakiness"
+				}
+				return RetryNone
+			},
+		}
+		run, attempts := failing(customErr)
+		err := tf.withRetry(context.Background(), policy, "plan", run)
+		if err != nil || *attempts != 2 {
+			t.Fatalf("expected success on attempt 2, got %d: %v", *attempts, err)
+		}
+	})
+
+	t.Run("cancelled while waiting", func(t *testing.T) {
+		tf, _ := newTF(t)
+		tf.SetRetryPolicy(&RetryPolicy{InitialBackoff: time.Hour})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		run, attempts := failing(lockErr)
+		err := tf.withRetry(ctx, nil, "plan", run)
+		if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, lockErr) || *attempts != 1 {
+			t.Fatalf("expected cancellation after a single attempt, got %d: %v", *attempts, err)
+		}
+	})
+}
+
+func TestPlan_retryGenerateConfigOut(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test relies on a shell script standing in for Terraform")
+	}
+
+	// fails transiently after writing the generated configuration the first
+	// time, and refuses to overwrite it like Terraform does
+	script := `#!/bin/sh
+case "$1" in
+version)
+  echo '{"terraform_version":"1.9.0","platform":"linux_amd64","provider_selections":{}}'
+  ;;
+plan)
+  for arg; do
+    case "$arg" in -generate-config-out=*) out="${arg#*=}" ;; esac
+  done
+  if [ -e "$out" ]; then
+    echo "Error: Target generated file already exists" >&2
+    exit 1
+  fi
+  echo 'resource "terraform_data" "foo" {}' > "$out"
+  if [ ! -e "$0.failed" ]; then
+    touch "$0.failed"
+    echo "Error: read tcp: connection reset by peer" >&2
+    exit 1
+  fi
+  ;;
+esac
+`
+	execPath := filepath.Join(t.TempDir(), "terraform")
+	err := os.WriteFile(execPath, []byte(script), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tf, err := NewTerraform(t.TempDir(), execPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// empty env, to avoid environ mismatch in testing
+	tf.SetEnv(map[string]string{})
+	tf.SetRetryPolicy(&RetryPolicy{InitialBackoff: time.Millisecond, Jitter: -1})
+
+	_, err = tf.Plan(context.Background(), GenerateConfigOut("generated.tf"))
+	if err != nil {
+		t.Fatalf("expected plan to succeed on retry, got %s", err)
+	}
+
+	t.Run("existing file", func(t *testing.T) {
+		err := os.WriteFile(filepath.Join(tf.WorkingDir(), "existing.tf"), []byte("# keep"), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.Remove(execPath + ".failed")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = tf.Plan(context.Background(), GenerateConfigOut("existing.tf"))
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+		b, err := os.ReadFile(filepath.Join(tf.WorkingDir(), "existing.tf"))
+		if err != nil || string(b) != "# keep" {
+			t.Fatalf("expected existing file to be kept, got %q (%v)", b, err)
+		}
+	})
+}
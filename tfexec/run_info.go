@@ -0,0 +1,117 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
+
+// RunInfo describes a single run of the Terraform CLI, see
+// SetRunInfoCallback.
+type RunInfo struct {
+	// Subcommand is the subcommand run, e.g. "plan" or "state pull".
+	Subcommand string
+
+	// Args are the arguments passed to Terraform, excluding the executable,
+	// with the values of -var and -backend-config assignments redacted.
+	Args []string
+
+	// TerraformVersion is the version of the executable, if it was already
+	// determined for version checks, or nil.
+	TerraformVersion *version.Version
+
+	Start    time.Time
+	WallTime time.Duration
+
+	// UserTime and SystemTime are the CPU time used by Terraform, including
+	// provider plugins which exited before it.
+	UserTime   time.Duration
+	SystemTime time.Duration
+
+	// MaxRSS is the maximum resident set size of Terraform and those provider
+	// plugins in bytes. It is only available on Linux, and zero elsewhere.
+	MaxRSS int64
+
+	// ExitCode is the exit code of Terraform, or -1 if it did not start or
+	// was terminated by a signal.
+	ExitCode int
+
+	// Err is the error returned for the run, if any.
+	Err error
+}
+
+// SetRunInfoCallback sets a callback called with the RunInfo of every
+// Terraform command run by this instance once it has finished, including
+// failed commands. The callback is called synchronously, before the command's
+// result is returned. Pass nil to remove it.
+func (tf *Terraform) SetRunInfoCallback(cb func(*RunInfo)) {
+	tf.runInfoCallback = cb
+}
+
+// reportRunInfo calls the RunInfo callback, if set, and the AfterRun hooks
+// for cmd which was started at the given time and returned err.
+func (tf *Terraform) reportRunInfo(ctx context.Context, cmd *exec.Cmd, start time.Time, err error) {
+	if tf.runInfoCallback == nil && len(tf.afterRun) == 0 {
+		return
+	}
+
+	info := &RunInfo{
+		Subcommand: subcommandOf(cmd.Args[1:]),
+		Args:       redactArgs(cmd.Args[1:]),
+		Start:      start,
+		WallTime:   time.Since(start),
+		ExitCode:   -1,
+		Err:        err,
+
+		TerraformVersion: tf.knownVersion.Load(),
+	}
+
+	if ps := cmd.ProcessState; ps != nil {
+		info.ExitCode = ps.ExitCode()
+		info.UserTime = ps.UserTime()
+		info.SystemTime = ps.SystemTime()
+		info.MaxRSS = maxRSS(ps)
+	}
+
+	if tf.runInfoCallback != nil {
+		tf.runInfoCallback(info)
+	}
+	for i := len(tf.afterRun) - 1; i >= 0; i-- {
+		tf.afterRun[i](ctx, info)
+	}
+}
+
+const redacted = "<redacted>"
+
+// redactArgs returns a copy of args with the values of -var and
+// -backend-config assignments redacted, since they may contain secrets.
+func redactArgs(args []string) []string {
+	redactedArgs := make([]string, len(args))
+	for i, arg := range args {
+		switch {
+		case i > 0 && args[i-1] == "-var":
+			arg = redactAssignment(arg)
+		case strings.HasPrefix(arg, "-var="), strings.HasPrefix(arg, "-backend-config="):
+			flag, value, _ := strings.Cut(arg, "=")
+			if strings.Contains(value, "=") {
+				arg = flag + "=" + redactAssignment(value)
+			}
+		}
+		redactedArgs[i] = arg
+	}
+	return redactedArgs
+}
+
+func redactAssignment(assignment string) string {
+	name, _, ok := strings.Cut(assignment, "=")
+	if !ok {
+		return assignment
+	}
+	return name + "=" + redacted
+}
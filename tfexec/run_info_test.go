@@ -0,0 +1,89 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-exec/tfexec/internal/testutil"
+)
+
+func TestRedactArgs(t *testing.T) {
+	args := []string{
+		"plan",
+		"-no-color",
+		"-var-file=secrets.tfvars",
+		"-backend-config=backend.hcl",
+		"-backend-config=access_key=AKIAEXAMPLE",
+		"-var=token=hunter2",
+		"-var",
+		"password=a=b",
+		"-var",
+		"invalid",
+		"-target=aws_instance.web",
+	}
+
+	expected := []string{
+		"plan",
+		"-no-color",
+		"-var-file=secrets.tfvars",
+		"-backend-config=backend.hcl",
+		"-backend-config=access_key=<redacted>",
+		"-var=token=<redacted>",
+		"-var",
+		"password=<redacted>",
+		"-var",
+		"invalid",
+		"-target=aws_instance.web",
+	}
+
+	actual := redactArgs(args)
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Fatalf("unexpected args: %s", diff)
+	}
+	if args[5] != "-var=token=hunter2" {
+		t.Fatal("expected args not to be modified")
+	}
+}
+
+func TestRunInfoCallback_version(t *testing.T) {
+	tf, err := NewTerraform(t.TempDir(), tfVersion(t, testutil.Latest_v1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var infos []*RunInfo
+	tf.SetRunInfoCallback(func(info *RunInfo) {
+		infos = append(infos, info)
+	})
+
+	done := make(chan error)
+	go func() {
+		_, _, err := tf.Version(context.Background(), true)
+		if err == nil {
+			_, _, err = tf.Version(context.Background(), true)
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(30 * time.Second):
+		t.Fatal("timed out waiting for version")
+	}
+
+	if len(infos) != 2 || infos[0].TerraformVersion != nil || infos[1].TerraformVersion == nil {
+		t.Fatalf("expected version to be reported once known, got %#v", infos)
+	}
+	if diff := cmp.Diff([]string{"version", "-json"}, infos[1].Args); diff != "" {
+		t.Fatalf("unexpected args: %s", diff)
+	}
+}
@@ -15,6 +15,7 @@ import (
 type showConfig struct {
 	reattachInfo ReattachInfo
 	jsonNumber   *UseJSONNumberOption
+	retryPolicy  *RetryPolicy
 }
 
 var defaultShowOptions = showConfig{}
@@ -27,6 +28,10 @@ func (opt *ReattachOption) configureShow(conf *showConfig) {
 	conf.reattachInfo = opt.info
 }
 
+func (opt *RetryOption) configureShow(conf *showConfig) {
+	conf.retryPolicy = opt.policy
+}
+
 func (opt *UseJSONNumberOption) configureShow(conf *showConfig) {
 	conf.jsonNumber = opt
 }
@@ -54,16 +59,19 @@ func (tf *Terraform) Show(ctx context.Context, opts ...ShowOption) (*tfjson.Stat
 		mergeEnv[reattachEnvVar] = reattachStr
 	}
 
-	showCmd := tf.showCmd(ctx, true, mergeEnv)
-
 	var ret tfjson.State
-	ret.UseJSONNumber(true)
+	err = tf.withRetry(ctx, c.retryPolicy, "show", func() error {
+		showCmd := tf.showCmd(ctx, true, mergeEnv)
 
-	if c.jsonNumber != nil {
-		ret.UseJSONNumber(c.jsonNumber.useJSONNumber)
-	}
+		ret = tfjson.State{}
+		ret.UseJSONNumber(true)
 
-	err = tf.runTerraformCmdJSON(ctx, showCmd, &ret)
+		if c.jsonNumber != nil {
+			ret.UseJSONNumber(c.jsonNumber.useJSONNumber)
+		}
+
+		return tf.runTerraformCmdJSON(ctx, showCmd, &ret)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -102,16 +110,19 @@ func (tf *Terraform) ShowStateFile(ctx context.Context, statePath string, opts .
 		mergeEnv[reattachEnvVar] = reattachStr
 	}
 
-	showCmd := tf.showCmd(ctx, true, mergeEnv, statePath)
-
 	var ret tfjson.State
-	ret.UseJSONNumber(true)
+	err = tf.withRetry(ctx, c.retryPolicy, "show", func() error {
+		showCmd := tf.showCmd(ctx, true, mergeEnv, statePath)
 
-	if c.jsonNumber != nil {
-		ret.UseJSONNumber(c.jsonNumber.useJSONNumber)
-	}
+		ret = tfjson.State{}
+		ret.UseJSONNumber(true)
 
-	err = tf.runTerraformCmdJSON(ctx, showCmd, &ret)
+		if c.jsonNumber != nil {
+			ret.UseJSONNumber(c.jsonNumber.useJSONNumber)
+		}
+
+		return tf.runTerraformCmdJSON(ctx, showCmd, &ret)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -150,15 +161,18 @@ func (tf *Terraform) ShowPlanFile(ctx context.Context, planPath string, opts ...
 		mergeEnv[reattachEnvVar] = reattachStr
 	}
 
-	showCmd := tf.showCmd(ctx, true, mergeEnv, planPath)
-
 	var ret tfjson.Plan
+	err = tf.withRetry(ctx, c.retryPolicy, "show", func() error {
+		showCmd := tf.showCmd(ctx, true, mergeEnv, planPath)
 
-	if c.jsonNumber != nil {
-		ret.UseJSONNumber(c.jsonNumber.useJSONNumber)
-	}
+		ret = tfjson.Plan{}
 
-	err = tf.runTerraformCmdJSON(ctx, showCmd, &ret)
+		if c.jsonNumber != nil {
+			ret.UseJSONNumber(c.jsonNumber.useJSONNumber)
+		}
+
+		return tf.runTerraformCmdJSON(ctx, showCmd, &ret)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -194,11 +208,14 @@ func (tf *Terraform) ShowPlanFileRaw(ctx context.Context, planPath string, opts
 		mergeEnv[reattachEnvVar] = reattachStr
 	}
 
-	showCmd := tf.showCmd(ctx, false, mergeEnv, planPath)
-
 	var outBuf strings.Builder
-	showCmd.Stdout = &outBuf
-	err := tf.runTerraformCmd(ctx, showCmd)
+	err := tf.withRetry(ctx, c.retryPolicy, "show", func() error {
+		showCmd := tf.showCmd(ctx, false, mergeEnv, planPath)
+
+		outBuf.Reset()
+		showCmd.Stdout = &outBuf
+		return tf.runTerraformCmd(ctx, showCmd)
+	})
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+)
+
+type stateIdentitiesConfig struct {
+	reattachInfo ReattachInfo
+	state        string
+}
+
+var defaultStateIdentitiesOptions = stateIdentitiesConfig{}
+
+// StateIdentitiesOption represents options used in the StateIdentities method.
+type StateIdentitiesOption interface {
+	configureStateIdentities(*stateIdentitiesConfig)
+}
+
+func (opt *ReattachOption) configureStateIdentities(conf *stateIdentitiesConfig) {
+	conf.reattachInfo = opt.info
+}
+
+func (opt *StateOption) configureStateIdentities(conf *stateIdentitiesConfig) {
+	conf.state = opt.path
+}
+
+// StateIdentity is the identity of a resource instance in state.
+type StateIdentity struct {
+	Address  string
+	Identity map[string]interface{}
+}
+
+// ImportSpec returns an ImportSpec importing the same remote object by its
+// identity, e.g. to import it into the state of another configuration.
+func (si StateIdentity) ImportSpec() ImportSpec {
+	return ImportSpec{
+		Address:  si.Address,
+		Identity: si.Identity,
+	}
+}
+
+// StateIdentities represents the terraform state identities subcommand with
+// the -json flag, as introduced in Terraform 1.12. It returns the identities
+// of all resource instances in state which have one, sorted by address.
+func (tf *Terraform) StateIdentities(ctx context.Context, opts ...StateIdentitiesOption) ([]StateIdentity, error) {
+	err := tf.compatible(ctx, tf1_12_0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("terraform state identities was added in 1.12.0: %w", err)
+	}
+
+	cmd, err := tf.stateIdentitiesCmd(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret bytes.Buffer
+	cmd.Stdout = &ret
+	err = tf.runTerraformCmd(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]map[string]interface{}
+	err = json.Unmarshal(ret.Bytes(), &raw)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse state identities: %w", err)
+	}
+
+	identities := make([]StateIdentity, 0, len(raw))
+	for addr, identity := range raw {
+		identities = append(identities, StateIdentity{
+			Address:  addr,
+			Identity: identity,
+		})
+	}
+	sort.Slice(identities, func(i, j int) bool {
+		return identities[i].Address < identities[j].Address
+	})
+
+	return identities, nil
+}
+
+func (tf *Terraform) stateIdentitiesCmd(ctx context.Context, opts ...StateIdentitiesOption) (*exec.Cmd, error) {
+	c := defaultStateIdentitiesOptions
+
+	for _, o := range opts {
+		o.configureStateIdentities(&c)
+	}
+
+	args := []string{"state", "identities", "-json"}
+
+	// string opts: only pass if set
+	if c.state != "" {
+		args = append(args, "-state="+c.state)
+	}
+
+	mergeEnv := map[string]string{}
+	if c.reattachInfo != nil {
+		reattachStr, err := c.reattachInfo.marshalString()
+		if err != nil {
+			return nil, err
+		}
+		mergeEnv[reattachEnvVar] = reattachStr
+	}
+
+	return tf.buildTerraformCmd(ctx, mergeEnv, args...), nil
+}
@@ -0,0 +1,50 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-exec/tfexec/internal/testutil"
+)
+
+func TestStateIdentitiesCmd(t *testing.T) {
+	td := t.TempDir()
+
+	tf, err := NewTerraform(td, tfVersion(t, testutil.Latest_v1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// empty env, to avoid environ mismatch in testing
+	tf.SetEnv(map[string]string{})
+
+	t.Run("defaults", func(t *testing.T) {
+		stateIdentitiesCmd, err := tf.stateIdentitiesCmd(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertCmd(t, []string{
+			"state",
+			"identities",
+			"-json",
+		}, nil, stateIdentitiesCmd)
+	})
+
+	t.Run("override all defaults", func(t *testing.T) {
+		stateIdentitiesCmd, err := tf.stateIdentitiesCmd(context.Background(), State("teststate"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertCmd(t, []string{
+			"state",
+			"identities",
+			"-json",
+			"-state=teststate",
+		}, nil, stateIdentitiesCmd)
+	})
+}
@@ -0,0 +1,201 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// LockInfo describes a state lock held by another Terraform operation, as
+// printed by Terraform when it fails to acquire the lock.
+type LockInfo struct {
+	ID        string
+	Path      string
+	Operation string
+	Who       string
+	Version   string
+
+	// Created is the time the lock was acquired, or the zero time if it
+	// could not be parsed.
+	Created time.Time
+
+	Info string
+}
+
+// Age returns how long ago the lock was acquired, or zero if the creation
+// time is unknown.
+func (li *LockInfo) Age() time.Duration {
+	if li.Created.IsZero() {
+		return 0
+	}
+	return time.Since(li.Created)
+}
+
+// ErrStateLocked is returned when a command fails because the state is locked
+// by another operation. The message is that of the original error.
+type ErrStateLocked struct {
+	LockInfo *LockInfo
+
+	err error
+}
+
+func (e *ErrStateLocked) Error() string {
+	return e.err.Error()
+}
+
+func (e *ErrStateLocked) Unwrap() error {
+	return e.err
+}
+
+// lockCreatedLayout is the format of time.Time.String, used by Terraform to
+// print the creation time of a lock.
+const lockCreatedLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// parseLockInfo parses the Lock Info block Terraform prints to stderr when it
+// fails to acquire the state lock. It returns nil if there is none.
+func parseLockInfo(stderr string) *LockInfo {
+	lines := strings.Split(strings.Replace(stderr, "\r\n", "\n", -1), "\n")
+
+	var info *LockInfo
+	for _, l := range lines {
+		// strip the borders of diagnostics printed with color
+		l = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(l), "│"))
+
+		if info == nil {
+			if l == "Lock Info:" {
+				info = &LockInfo{}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(l, ":")
+		if !ok {
+			break
+		}
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "ID":
+			info.ID = value
+		case "Path":
+			info.Path = value
+		case "Operation":
+			info.Operation = value
+		case "Who":
+			info.Who = value
+		case "Version":
+			info.Version = value
+		case "Created":
+			created, err := time.Parse(lockCreatedLayout, value)
+			if err == nil {
+				info.Created = created
+			}
+		case "Info":
+			info.Info = value
+		default:
+			return info
+		}
+	}
+
+	if info == nil || info.ID == "" {
+		return nil
+	}
+	return info
+}
+
+// lockAttrs returns the structured logging attributes describing a lock.
+func lockAttrs(info *LockInfo) []slog.Attr {
+	return []slog.Attr{
+		slog.String("lock_id", info.ID),
+		slog.String("lock_who", info.Who),
+		slog.Duration("lock_age", info.Age()),
+	}
+}
+
+// LockRecovery configures WithLockRecovery.
+type LockRecovery struct {
+	// Timeout is how long to keep retrying while the state is locked. If
+	// zero, retries continue until the context is done.
+	Timeout time.Duration
+
+	// PollInterval is the delay between attempts, 5s if zero.
+	PollInterval time.Duration
+
+	// StaleAfter is the age after which a lock is considered stale. Stale
+	// locks are forcibly unlocked if HolderDead confirms that their holder is
+	// gone. If zero, locks are never forcibly unlocked.
+	StaleAfter time.Duration
+
+	// HolderDead reports whether the holder of a stale lock is known to be
+	// dead, e.g. by looking up the CI job or host named by LockInfo.Who. It
+	// is required for locks to be forcibly unlocked.
+	HolderDead func(ctx context.Context, info *LockInfo) (bool, error)
+}
+
+// WithLockRecovery calls run, retrying while it fails with ErrStateLocked
+// until the Timeout of the given LockRecovery elapses or ctx is done, in
+// which case the last error is returned.
+//
+// If the lock is older than StaleAfter and HolderDead reports that its holder
+// is dead, the lock is forcibly unlocked via ForceUnlock before retrying. A
+// lock is forcibly unlocked at most once, and only if both are set.
+func (tf *Terraform) WithLockRecovery(ctx context.Context, recovery *LockRecovery, run func(ctx context.Context) error) error {
+	pollInterval := recovery.PollInterval
+	if pollInterval == 0 {
+		pollInterval = 5 * time.Second
+	}
+
+	var deadline time.Time
+	if recovery.Timeout > 0 {
+		deadline = time.Now().Add(recovery.Timeout)
+	}
+
+	unlocked := map[string]bool{}
+	for {
+		err := run(ctx)
+
+		var lockErr *ErrStateLocked
+		if !errors.As(err, &lockErr) {
+			return err
+		}
+		info := lockErr.LockInfo
+
+		if recovery.StaleAfter > 0 && recovery.HolderDead != nil && !unlocked[info.ID] && info.Age() > recovery.StaleAfter {
+			dead, hdErr := recovery.HolderDead(ctx, info)
+			if hdErr != nil {
+				return fmt.Errorf("unable to check holder of state lock %s: %w", info.ID, hdErr)
+			}
+			if dead {
+				tf.logf(ctx, slog.LevelWarn, lockAttrs(info), "force unlocking state lock %s held by %s for %s", info.ID, info.Who, info.Age().Round(time.Second))
+				unlocked[info.ID] = true
+
+				fuErr := tf.ForceUnlock(ctx, info.ID)
+				if fuErr != nil {
+					return fmt.Errorf("unable to force unlock state lock %s: %w", info.ID, fuErr)
+				}
+				continue
+			}
+		}
+
+		if !deadline.IsZero() && time.Now().Add(pollInterval).After(deadline) {
+			return err
+		}
+
+		tf.logf(ctx, slog.LevelInfo, append(lockAttrs(info), slog.Duration("delay", pollInterval)),
+			"state is locked by %s (lock %s), retrying in %s", info.Who, info.ID, pollInterval)
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w\nwaiting for state lock aborted: %w", err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-exec/tfexec/internal/testutil"
+)
+
+const lockedStderr = `
+Error: Error acquiring the state lock
+
+Error message: ConditionalCheckFailedException: The conditional request failed
+Lock Info:
+  ID:        4b8f1c2e-7a1d-4c1e-9f3a-1d2e3f4a5b6c
+  Path:      my-bucket/prod/terraform.tfstate
+  Operation: OperationTypeApply
+  Who:       ci@runner-42
+  Version:   1.5.7
+  Created:   2023-10-01 12:34:56.789012 +0000 UTC
+  Info:      
+
+
+Terraform acquires a state lock to protect the state from being written
+by multiple users at the same time. Please resolve the issue above and try
+again. For most commands, you can disable locking with the "-lock=false"
+flag, but this is not recommended.
+`
+
+func TestParseLockInfo(t *testing.T) {
+	expected := &LockInfo{
+		ID:        "4b8f1c2e-7a1d-4c1e-9f3a-1d2e3f4a5b6c",
+		Path:      "my-bucket/prod/terraform.tfstate",
+		Operation: "OperationTypeApply",
+		Who:       "ci@runner-42",
+		Version:   "1.5.7",
+		Created:   time.Date(2023, 10, 1, 12, 34, 56, 789012000, time.UTC),
+	}
+
+	t.Run("plain", func(t *testing.T) {
+		actual := parseLockInfo(lockedStderr)
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Fatalf("unexpected lock info: %s", diff)
+		}
+	})
+
+	t.Run("color", func(t *testing.T) {
+		var b strings.Builder
+		b.WriteString("╷\n")
+		for _, l := range strings.Split(strings.TrimSpace(lockedStderr), "\n") {
+			b.WriteString("│ " + l + "\n")
+		}
+		b.WriteString("╵\n")
+
+		actual := parseLockInfo(b.String())
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Fatalf("unexpected lock info: %s", diff)
+		}
+	})
+
+	t.Run("not locked", func(t *testing.T) {
+		if info := parseLockInfo("\nError: Unsupported argument\n"); info != nil {
+			t.Fatalf("expected no lock info, got %#v", info)
+		}
+	})
+}
+
+func TestCmdError(t *testing.T) {
+	exitErr := &exec.ExitError{}
+
+	err := cmdError(exitErr, lockedStderr)
+	var lockErr *ErrStateLocked
+	if !errors.As(err, &lockErr) {
+		t.Fatalf("expected ErrStateLocked, got %T", err)
+	}
+	if lockErr.LockInfo.Who != "ci@runner-42" {
+		t.Fatalf("unexpected lock info: %#v", lockErr.LockInfo)
+	}
+	if !errors.Is(err, exitErr) || !strings.Contains(err.Error(), "Error acquiring the state lock") {
+		t.Fatalf("expected original error to be preserved, got %q", err)
+	}
+	if ClassifyRetryable(err) != RetryStateLock {
+		t.Fatalf("expected state lock to be retryable")
+	}
+
+	err = cmdError(exitErr, "\nError: Unsupported argument\n")
+	if errors.As(err, &lockErr) {
+		t.Fatal("expected unlocked error not to be ErrStateLocked")
+	}
+}
+
+func TestWithLockRecovery(t *testing.T) {
+	tf, err := NewTerraform(t.TempDir(), tfVersion(t, testutil.Latest_v1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lockErr := func(created time.Time) error {
+		return &ErrStateLocked{
+			LockInfo: &LockInfo{ID: "lock-id", Who: "ci@runner-42", Created: created},
+			err:      errors.New("Error acquiring the state lock"),
+		}
+	}
+
+	t.Run("released", func(t *testing.T) {
+		attempts := 0
+		err := tf.WithLockRecovery(context.Background(), &LockRecovery{PollInterval: time.Millisecond}, func(ctx context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return lockErr(time.Now())
+			}
+			return nil
+		})
+		if err != nil || attempts != 3 {
+			t.Fatalf("expected success on attempt 3, got %d: %v", attempts, err)
+		}
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		otherErr := errors.New("other")
+		err := tf.WithLockRecovery(context.Background(), &LockRecovery{PollInterval: time.Millisecond}, func(ctx context.Context) error {
+			return otherErr
+		})
+		if err != otherErr {
+			t.Fatalf("expected other error, got %v", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		attempts := 0
+		err := tf.WithLockRecovery(context.Background(), &LockRecovery{Timeout: 50 * time.Millisecond, PollInterval: 10 * time.Millisecond}, func(ctx context.Context) error {
+			attempts++
+			return lockErr(time.Now())
+		})
+		var e *ErrStateLocked
+		if !errors.As(err, &e) || attempts < 2 || attempts > 6 {
+			t.Fatalf("expected ErrStateLocked after a few attempts, got %d: %v", attempts, err)
+		}
+	})
+
+	t.Run("stale lock with live holder", func(t *testing.T) {
+		attempts := 0
+		checked := 0
+		err := tf.WithLockRecovery(context.Background(), &LockRecovery{
+			Timeout:      20 * time.Millisecond,
+			PollInterval: 5 * time.Millisecond,
+			StaleAfter:   time.Hour,
+			HolderDead: func(ctx context.Context, info *LockInfo) (bool, error) {
+				checked++
+				return false, nil
+			},
+		}, func(ctx context.Context) error {
+			attempts++
+			return lockErr(time.Now().Add(-2 * time.Hour))
+		})
+		var e *ErrStateLocked
+		if !errors.As(err, &e) || checked != attempts {
+			t.Fatalf("expected ErrStateLocked with holder checked on every attempt, got %d/%d: %v", checked, attempts, err)
+		}
+	})
+
+	t.Run("fresh lock", func(t *testing.T) {
+		err := tf.WithLockRecovery(context.Background(), &LockRecovery{
+			Timeout:      20 * time.Millisecond,
+			PollInterval: 5 * time.Millisecond,
+			StaleAfter:   time.Hour,
+			HolderDead: func(ctx context.Context, info *LockInfo) (bool, error) {
+				t.Fatal("holder of fresh lock should not be checked")
+				return true, nil
+			},
+		}, func(ctx context.Context) error {
+			return lockErr(time.Now())
+		})
+		var e *ErrStateLocked
+		if !errors.As(err, &e) {
+			t.Fatalf("expected ErrStateLocked, got %v", err)
+		}
+	})
+
+	t.Run("stale lock with dead holder", func(t *testing.T) {
+		// there is no lock to force unlock in the empty working directory,
+		// so ForceUnlock is attempted and fails
+		err := tf.WithLockRecovery(context.Background(), &LockRecovery{
+			PollInterval: time.Millisecond,
+			StaleAfter:   time.Hour,
+			HolderDead: func(ctx context.Context, info *LockInfo) (bool, error) {
+				return true, nil
+			},
+		}, func(ctx context.Context) error {
+			return lockErr(time.Now().Add(-2 * time.Hour))
+		})
+		if err == nil || !strings.HasPrefix(err.Error(), "unable to force unlock state lock lock-id") {
+			t.Fatalf("expected force unlock to be attempted, got %v", err)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := tf.WithLockRecovery(ctx, &LockRecovery{PollInterval: time.Hour}, func(ctx context.Context) error {
+			return lockErr(time.Now())
+		})
+		var e *ErrStateLocked
+		if !errors.As(err, &e) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected ErrStateLocked and cancellation, got %v", err)
+		}
+	})
+}
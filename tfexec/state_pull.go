@@ -11,23 +11,28 @@ import (
 
 type statePullConfig struct {
 	reattachInfo ReattachInfo
+	retryPolicy  *RetryPolicy
 }
 
 var defaultStatePullConfig = statePullConfig{}
 
 type StatePullOption interface {
-	configureShow(*statePullConfig)
+	configureStatePull(*statePullConfig)
 }
 
 func (opt *ReattachOption) configureStatePull(conf *statePullConfig) {
 	conf.reattachInfo = opt.info
 }
 
+func (opt *RetryOption) configureStatePull(conf *statePullConfig) {
+	conf.retryPolicy = opt.policy
+}
+
 func (tf *Terraform) StatePull(ctx context.Context, opts ...StatePullOption) (string, error) {
 	c := defaultStatePullConfig
 
 	for _, o := range opts {
-		o.configureShow(&c)
+		o.configureStatePull(&c)
 	}
 
 	mergeEnv := map[string]string{}
@@ -39,11 +44,14 @@ func (tf *Terraform) StatePull(ctx context.Context, opts ...StatePullOption) (st
 		mergeEnv[reattachEnvVar] = reattachStr
 	}
 
-	cmd := tf.statePullCmd(ctx, mergeEnv)
-
 	var ret bytes.Buffer
-	cmd.Stdout = &ret
-	err := tf.runTerraformCmd(ctx, cmd)
+	err := tf.withRetry(ctx, c.retryPolicy, "state pull", func() error {
+		cmd := tf.statePullCmd(ctx, mergeEnv)
+
+		ret.Reset()
+		cmd.Stdout = &ret
+		return tf.runTerraformCmd(ctx, cmd)
+	})
 	if err != nil {
 		return "", err
 	}
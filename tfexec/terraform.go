@@ -10,12 +10,17 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"log/slog"
+	"maps"
 	"os"
 	"runtime"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-version"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type printfer interface {
@@ -39,6 +44,7 @@ type printfer interface {
 // setting them through SetEnv:
 //
 //   - TF_APPEND_USER_AGENT
+//   - TF_DATA_DIR
 //   - TF_IN_AUTOMATION
 //   - TF_INPUT
 //   - TF_LOG
@@ -54,10 +60,40 @@ type Terraform struct {
 	skipProviderVerify bool
 	env                map[string]string
 
+	// TF_WORKSPACE environment variable, only set on handles returned by
+	// ForWorkspace
+	workspace string
+
+	// TF_DATA_DIR environment variable
+	dataDir string
+
+	// guard is checked before applying changes, see SetGuard
+	guard *ChangeGuard
+
+	// policies are evaluated before applying changes, see SetPolicies
+	policies []PolicyEvaluator
+
+	// retryPolicy retries idempotent commands, see SetRetryPolicy
+	retryPolicy *RetryPolicy
+
 	stdout io.Writer
 	stderr io.Writer
 	logger printfer
 
+	// slogger takes precedence over logger, see SetSlogLogger
+	slogger *slog.Logger
+
+	// runInfoCallback is called after every command, see SetRunInfoCallback
+	runInfoCallback func(*RunInfo)
+
+	// beforeRun and afterRun are hooks called around every command, see
+	// BeforeRun and AfterRun
+	beforeRun []BeforeRunFunc
+	afterRun  []AfterRunFunc
+
+	// tracerProvider traces every command, see SetTracerProvider
+	tracerProvider trace.TracerProvider
+
 	// TF_LOG environment variable, defaults to TRACE if logPath is set.
 	log string
 
@@ -70,20 +106,31 @@ type Terraform struct {
 	// TF_LOG_PROVIDER environment variable
 	logProvider string
 
+	// logCapture captures the logs of each command, see SetLogCapture
+	logCapture *LogCapture
+
 	// waitDelay represents the WaitDelay field of the [exec.Cmd] of Terraform
 	waitDelay time.Duration
 
+	// cancelEscalation stops cancelled commands, see SetCancelEscalation
+	cancelEscalation *CancelEscalation
+
 	// enableLegacyPipeClosing closes the stdout/stderr pipes before calling [exec.Cmd.Wait]
 	enableLegacyPipeClosing bool
 
 	versionLock  sync.Mutex
 	execVersion  *version.Version
 	provVersions map[string]*version.Version
+
+	// knownVersion mirrors execVersion, so it can be read without waiting
+	// for versionLock, which is held while the version command runs
+	knownVersion atomic.Pointer[version.Version]
 }
 
 // NewTerraform returns a Terraform struct with default values for all fields.
 // If a blank execPath is supplied, NewTerraform will error.
-// Use hc-install or output from os.LookPath to get a desirable execPath.
+// Use hc-install or output from os.LookPath to get a desirable execPath, or
+// NewTerraformWithConstraint to look up an executable by version constraint.
 func NewTerraform(workingDir string, execPath string) (*Terraform, error) {
 	if workingDir == "" {
 		return nil, fmt.Errorf("Terraform cannot be initialised with empty workdir")
@@ -201,6 +248,17 @@ func (tf *Terraform) SetLogProvider(logProvider string) error {
 	return nil
 }
 
+// SetDataDir sets the TF_DATA_DIR environment variable for Terraform CLI
+// execution, i.e. the directory used instead of .terraform to store the
+// initialized backend, modules and providers. A relative path is interpreted
+// relative to the working directory.
+//
+// Pass an empty string to use the default data directory.
+func (tf *Terraform) SetDataDir(path string) error {
+	tf.dataDir = path
+	return nil
+}
+
 // SetAppendUserAgent sets the TF_APPEND_USER_AGENT environment variable for
 // Terraform CLI execution.
 func (tf *Terraform) SetAppendUserAgent(ua string) error {
@@ -245,6 +303,67 @@ func (tf *Terraform) SetEnableLegacyPipeClosing(enabled bool) error {
 	return nil
 }
 
+// ForWorkspace returns a new Terraform instance sharing the configuration of
+// tf which runs every command against the given workspace, by setting the
+// TF_WORKSPACE environment variable instead of relying on the workspace
+// selected in the data directory.
+//
+// Handles for different workspaces can be used concurrently against the same
+// working directory. Workspace selection (WorkspaceSelect, EnsureWorkspace
+// and WithWorkspace) is not supported on the returned instance.
+func (tf *Terraform) ForWorkspace(workspace string) *Terraform {
+	wtf := tf.clone()
+	wtf.workspace = workspace
+	return wtf
+}
+
+// clone returns a copy of tf which can be configured independently.
+func (tf *Terraform) clone() *Terraform {
+	tf.versionLock.Lock()
+	defer tf.versionLock.Unlock()
+
+	var env map[string]string
+	if tf.env != nil {
+		env = make(map[string]string, len(tf.env))
+		maps.Copy(env, tf.env)
+	}
+
+	ctf := &Terraform{
+		execPath:                tf.execPath,
+		workingDir:              tf.workingDir,
+		appendUserAgent:         tf.appendUserAgent,
+		disablePluginTLS:        tf.disablePluginTLS,
+		skipProviderVerify:      tf.skipProviderVerify,
+		env:                     env,
+		workspace:               tf.workspace,
+		dataDir:                 tf.dataDir,
+		guard:                   tf.guard,
+		policies:                tf.policies,
+		retryPolicy:             tf.retryPolicy,
+		stdout:                  tf.stdout,
+		stderr:                  tf.stderr,
+		logger:                  tf.logger,
+		slogger:                 tf.slogger,
+		runInfoCallback:         tf.runInfoCallback,
+		beforeRun:               slices.Clone(tf.beforeRun),
+		afterRun:                slices.Clone(tf.afterRun),
+		tracerProvider:          tf.tracerProvider,
+		log:                     tf.log,
+		logCore:                 tf.logCore,
+		logPath:                 tf.logPath,
+		logProvider:             tf.logProvider,
+		logCapture:              tf.logCapture,
+		waitDelay:               tf.waitDelay,
+		cancelEscalation:        tf.cancelEscalation,
+		enableLegacyPipeClosing: tf.enableLegacyPipeClosing,
+		execVersion:             tf.execVersion,
+		provVersions:            tf.provVersions,
+	}
+	ctf.knownVersion.Store(tf.execVersion)
+
+	return ctf
+}
+
 // WorkingDir returns the working directory for Terraform.
 func (tf *Terraform) WorkingDir() string {
 	return tf.workingDir
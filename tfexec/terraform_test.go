@@ -55,6 +55,7 @@ func TestSetEnv(t *testing.T) {
 
 		{true, "TF_LOG"},
 		{true, "TF_VAR_foo"},
+		{true, "TF_DATA_DIR"},
 	} {
 		t.Run(c.name, func(t *testing.T) {
 			err = tf.SetEnv(map[string]string{c.name: "foo"})
@@ -915,3 +916,74 @@ func tfVersion(t *testing.T, v string) string {
 
 	return tfCache.Version(t, v)
 }
+
+func TestForWorkspace(t *testing.T) {
+	td := t.TempDir()
+
+	tf, err := NewTerraform(td, tfVersion(t, testutil.Latest_v1))
+	if err != nil {
+		t.Fatalf("unexpected NewTerraform error: %s", err)
+	}
+
+	// Required so all testing environment variables are not copied.
+	err = tf.SetEnv(map[string]string{
+		"CLEARENV": "1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected SetEnv error: %s", err)
+	}
+
+	t.Run("pins TF_WORKSPACE", func(t *testing.T) {
+		wtf := tf.ForWorkspace("staging")
+
+		planCmd, err := wtf.planCmd(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected command error: %s", err)
+		}
+
+		assertCmd(t, []string{
+			"plan",
+			"-no-color",
+			"-input=false",
+			"-detailed-exitcode",
+			"-lock-timeout=0s",
+			"-lock=true",
+			"-parallelism=10",
+			"-refresh=true",
+		}, map[string]string{
+			"CLEARENV":     "1",
+			"TF_WORKSPACE": "staging",
+		}, planCmd)
+	})
+
+	t.Run("does not affect parent", func(t *testing.T) {
+		_ = tf.ForWorkspace("staging")
+
+		planCmd, err := tf.planCmd(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected command error: %s", err)
+		}
+
+		assertCmd(t, []string{
+			"plan",
+			"-no-color",
+			"-input=false",
+			"-detailed-exitcode",
+			"-lock-timeout=0s",
+			"-lock=true",
+			"-parallelism=10",
+			"-refresh=true",
+		}, map[string]string{
+			"CLEARENV": "1",
+		}, planCmd)
+	})
+
+	t.Run("workspace select unsupported", func(t *testing.T) {
+		wtf := tf.ForWorkspace("staging")
+
+		_, err := wtf.workspaceSelectCmd(context.Background(), "production")
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+}
@@ -0,0 +1,255 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/hashicorp/terraform-exec/tfexec"
+
+// SetTracerProvider enables OpenTelemetry tracing of the Terraform commands
+// run by this instance. Pass nil to disable tracing, which is the default.
+//
+// Each command is traced as a client span, a child of any span in the
+// context passed to the method running it, with the following attributes:
+//
+//   - terraform.subcommand, e.g. "plan" or "state pull"
+//   - terraform.args, with the values of -var and -backend-config assignments
+//     redacted
+//   - terraform.working_dir
+//   - terraform.workspace, if set via ForWorkspace
+//   - terraform.version, if already determined for version checks
+//   - terraform.exit_code
+//
+// For commands streaming machine-readable UI output with -json, e.g.
+// ApplyJSON, each resource operation reported by Terraform is traced as a
+// child span named after the action and resource address, e.g.
+// "create aws_instance.web".
+//
+// The span context is passed to Terraform via the TRACEPARENT and TRACESTATE
+// environment variables, so the traces Terraform 1.9 and later emit when
+// OTEL_TRACES_EXPORTER is set become part of the same trace.
+func (tf *Terraform) SetTracerProvider(tp trace.TracerProvider) {
+	tf.tracerProvider = tp
+}
+
+// startCmdSpan starts the span of cmd, which must not have been started yet,
+// and returns the function ending it with the command's error.
+func (tf *Terraform) startCmdSpan(ctx context.Context, cmd *exec.Cmd) func(error) {
+	if tf.tracerProvider == nil {
+		return func(error) {}
+	}
+
+	args := cmd.Args[1:]
+	subcommand := subcommandOf(args)
+
+	attrs := []attribute.KeyValue{
+		attribute.String("terraform.subcommand", subcommand),
+		attribute.StringSlice("terraform.args", redactArgs(args)),
+		attribute.String("terraform.working_dir", tf.workingDir),
+	}
+	if tf.workspace != "" {
+		attrs = append(attrs, attribute.String("terraform.workspace", tf.workspace))
+	}
+	if v := tf.knownVersion.Load(); v != nil {
+		attrs = append(attrs, attribute.String("terraform.version", v.String()))
+	}
+
+	tracer := tf.tracerProvider.Tracer(tracerName)
+	ctx, span := tracer.Start(ctx, "terraform "+subcommand,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	if traceparent := carrier.Get("traceparent"); traceparent != "" {
+		// later values take precedence over any set via SetEnv
+		cmd.Env = append(cmd.Env, "TRACEPARENT="+traceparent)
+		if tracestate := carrier.Get("tracestate"); tracestate != "" {
+			cmd.Env = append(cmd.Env, "TRACESTATE="+tracestate)
+		}
+	}
+
+	var ops *operationSpans
+	if slices.Contains(args, "-json") && streamingUICommands[subcommand] {
+		ops = &operationSpans{
+			ctx:    ctx,
+			tracer: tracer,
+			spans:  map[string]trace.Span{},
+		}
+		cmd.Stdout = mergeWriters(cmd.Stdout, ops)
+	}
+
+	return func(err error) {
+		if ops != nil {
+			ops.end()
+		}
+
+		exitCode := -1
+		if cmd.ProcessState != nil {
+			exitCode = cmd.ProcessState.ExitCode()
+			span.SetAttributes(attribute.Int("terraform.exit_code", exitCode))
+		}
+
+		// with -detailed-exitcode, 2 means success with changes
+		if err != nil && !(exitCode == 2 && slices.Contains(args, "-detailed-exitcode")) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, strings.SplitN(err.Error(), "\n", 2)[0])
+		}
+
+		span.End()
+	}
+}
+
+// streamingUICommands are the subcommands which stream machine-readable UI
+// messages with -json. Others, e.g. show, print a single, possibly very large
+// JSON document instead, which is not worth scanning for operations.
+var streamingUICommands = map[string]bool{
+	"plan":    true,
+	"apply":   true,
+	"destroy": true,
+	"refresh": true,
+	"import":  true,
+	"query":   true,
+}
+
+// maxOperationMessageSize is the size of the longest line scanned for
+// operations. Longer lines are skipped rather than buffered.
+const maxOperationMessageSize = 64 * 1024
+
+// subcommandOf returns the subcommand run with the given arguments, i.e. up
+// to two leading arguments which are not flags.
+func subcommandOf(args []string) string {
+	var words []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") || len(words) == 2 {
+			break
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
+
+// operationMessage is a machine-readable UI message reporting the progress
+// of an operation on a resource, see
+// https://developer.hashicorp.com/terraform/internals/machine-readable-ui
+type operationMessage struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"@timestamp"`
+	Hook      struct {
+		Resource struct {
+			Addr         string `json:"addr"`
+			ResourceType string `json:"resource_type"`
+		} `json:"resource"`
+		Action string `json:"action"`
+	} `json:"hook"`
+}
+
+// operationSpans traces resource operations reported in the machine-readable
+// output of a command written to it.
+type operationSpans struct {
+	ctx    context.Context
+	tracer trace.Tracer
+
+	buf   []byte
+	spans map[string]trace.Span
+
+	// skipping is true while discarding a line longer than
+	// maxOperationMessageSize
+	skipping bool
+}
+
+// Write never fails, so it does not interrupt the output of the command.
+func (o *operationSpans) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			if !o.skipping {
+				o.buf = append(o.buf, p...)
+			}
+			break
+		}
+
+		if !o.skipping {
+			o.buf = append(o.buf, p[:i]...)
+			o.observe(o.buf)
+		}
+		o.buf = o.buf[:0]
+		o.skipping = false
+		p = p[i+1:]
+	}
+
+	if len(o.buf) > maxOperationMessageSize {
+		o.buf = o.buf[:0]
+		o.skipping = true
+	}
+	return n, nil
+}
+
+func (o *operationSpans) observe(line []byte) {
+	if !bytes.Contains(line, []byte(`"hook"`)) {
+		return
+	}
+
+	var msg operationMessage
+	if json.Unmarshal(line, &msg) != nil {
+		return
+	}
+
+	addr := msg.Hook.Resource.Addr
+	phase, event, ok := strings.Cut(msg.Type, "_")
+	if !ok || addr == "" || (phase != "apply" && phase != "refresh") {
+		return
+	}
+	key := phase + " " + addr
+
+	switch event {
+	case "start":
+		action := msg.Hook.Action
+		if phase == "refresh" {
+			action = "refresh"
+		}
+		_, span := o.tracer.Start(o.ctx, action+" "+addr,
+			trace.WithTimestamp(msg.Timestamp),
+			trace.WithAttributes(
+				attribute.String("terraform.resource.address", addr),
+				attribute.String("terraform.resource.type", msg.Hook.Resource.ResourceType),
+				attribute.String("terraform.resource.action", action),
+			))
+		o.spans[key] = span
+	case "complete", "errored":
+		span, ok := o.spans[key]
+		if !ok {
+			return
+		}
+		if event == "errored" {
+			span.SetStatus(codes.Error, "operation failed")
+		}
+		span.End(trace.WithTimestamp(msg.Timestamp))
+		delete(o.spans, key)
+	}
+}
+
+// end ends the spans of operations which did not complete before the
+// command exited.
+func (o *operationSpans) end() {
+	for key, span := range o.spans {
+		span.SetStatus(codes.Error, "operation did not complete")
+		span.End()
+		delete(o.spans, key)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSubcommandOf(t *testing.T) {
+	for args, expected := range map[string][]string{
+		"plan":             {"plan", "-no-color", "-out=tfplan"},
+		"state pull":       {"state", "pull"},
+		"workspace select": {"workspace", "select", "-or-create", "foo"},
+		"workspace new":    {"workspace", "new", "foo"},
+		"":                 {"-version"},
+	} {
+		actual := subcommandOf(expected)
+		if actual != args {
+			t.Errorf("expected %q for %q, got %q", args, expected, actual)
+		}
+	}
+}
+
+func TestCmdSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	tf, err := NewTerraform(t.TempDir(), "terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tf.SetTracerProvider(tp)
+	wtf := tf.ForWorkspace("prod")
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "deploy")
+
+	cmd := wtf.buildTerraformCmd(ctx, nil, "apply", "-no-color", "-json", "-var", "password=hunter2")
+	endSpan := wtf.startCmdSpan(ctx, cmd)
+
+	traceparent := fmt.Sprintf("TRACEPARENT=00-%s-", parent.SpanContext().TraceID())
+	if !slices.ContainsFunc(cmd.Env, func(e string) bool { return len(e) > len(traceparent) && e[:len(traceparent)] == traceparent }) {
+		t.Fatalf("expected %s... in env, got %q", traceparent, cmd.Env)
+	}
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	output := fmt.Sprintf(`{"@level":"info","@message":"Terraform 1.9.0","@timestamp":"%[1]s","terraform":"1.9.0","type":"version","ui":"1.2"}
+{"@level":"info","@message":"null_resource.a: Creating...","@timestamp":"%[1]s","hook":{"resource":{"addr":"null_resource.a","resource_type":"null_resource"},"action":"create"},"type":"apply_start"}
+{"@level":"info","@message":"null_resource.b: Destroying...","@timestamp":"%[1]s","hook":{"resource":{"addr":"null_resource.b","resource_type":"null_resource"},"action":"delete"},"type":"apply_start"}
+{"@level":"info","@message":"null_resource.a: Creation complete","@timestamp":"%[2]s","hook":{"resource":{"addr":"null_resource.a","resource_type":"null_resource"},"action":"create"},"type":"apply_complete"}
+{"@level":"error","@message":"null_resource.b: Destruction errored","@timestamp":"%[2]s","hook":{"resource":{"addr":"null_resource.b","resource_type":"null_resource"},"action":"delete"},"type":"apply_errored"}
+{"@level":"info","@message":"null_resource.c: Refreshing state...","@timestamp":"%[1]s","hook":{"resource":{"addr":"null_resource.c","resource_type":"null_resource"}},"type":"refresh_start"}
+`, start.Format(time.RFC3339Nano), start.Add(time.Second).Format(time.RFC3339Nano))
+
+	// write in small chunks, splitting lines
+	for i := 0; i < len(output); i += 50 {
+		_, err := io.WriteString(cmd.Stdout, output[i:min(i+50, len(output))])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	endSpan(errors.New("exit status 1\n\nError: something went wrong"))
+	parent.End()
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, s := range exporter.GetSpans() {
+		spans[s.Name] = s
+	}
+	var names []string
+	for name := range spans {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	expectedNames := []string{"create null_resource.a", "delete null_resource.b", "deploy", "refresh null_resource.c", "terraform apply"}
+	if diff := cmp.Diff(expectedNames, names); diff != "" {
+		t.Fatalf("unexpected spans: %s", diff)
+	}
+
+	cmdSpan := spans["terraform apply"]
+	if cmdSpan.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Fatal("expected command span to be a child of the parent span")
+	}
+	if cmdSpan.Status.Code != codes.Error || cmdSpan.Status.Description != "exit status 1" {
+		t.Fatalf("unexpected status: %#v", cmdSpan.Status)
+	}
+	expectedAttrs := []attribute.KeyValue{
+		attribute.String("terraform.subcommand", "apply"),
+		attribute.StringSlice("terraform.args", []string{"apply", "-no-color", "-json", "-var", "password=<redacted>"}),
+		attribute.String("terraform.working_dir", tf.WorkingDir()),
+		attribute.String("terraform.workspace", "prod"),
+	}
+	if diff := cmp.Diff(expectedAttrs, cmdSpan.Attributes, cmp.AllowUnexported(attribute.Value{})); diff != "" {
+		t.Fatalf("unexpected attributes: %s", diff)
+	}
+
+	for name, expected := range map[string]codes.Code{
+		"create null_resource.a":  codes.Unset,
+		"delete null_resource.b":  codes.Error,
+		"refresh null_resource.c": codes.Error,
+	} {
+		s := spans[name]
+		if s.Parent.SpanID() != cmdSpan.SpanContext.SpanID() {
+			t.Fatalf("expected %s to be a child of the command span", name)
+		}
+		if s.Status.Code != expected {
+			t.Fatalf("expected status %s for %s, got %s", expected, name, s.Status.Code)
+		}
+		if !s.StartTime.Equal(start) {
+			t.Fatalf("expected %s to start at %s, got %s", name, start, s.StartTime)
+		}
+	}
+	if !spans["create null_resource.a"].EndTime.Equal(start.Add(time.Second)) {
+		t.Fatalf("unexpected end time: %s", spans["create null_resource.a"].EndTime)
+	}
+}
+
+func TestCmdSpan_disabled(t *testing.T) {
+	tf, err := NewTerraform(t.TempDir(), "terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := tf.buildTerraformCmd(context.Background(), nil, "apply", "-json")
+	env := slices.Clone(cmd.Env)
+	endSpan := tf.startCmdSpan(context.Background(), cmd)
+	endSpan(nil)
+
+	if !slices.Equal(env, cmd.Env) || cmd.Stdout != nil {
+		t.Fatal("expected command not to be modified")
+	}
+}
+
+func TestCmdSpan_documentCommands(t *testing.T) {
+	tf, err := NewTerraform(t.TempDir(), "terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tf.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	for _, args := range [][]string{
+		{"show", "-json"},
+		{"output", "-json"},
+		{"providers", "schema", "-json"},
+		{"version", "-json"},
+	} {
+		cmd := tf.buildTerraformCmd(context.Background(), nil, args...)
+		endSpan := tf.startCmdSpan(context.Background(), cmd)
+		if cmd.Stdout != nil {
+			t.Fatalf("expected output of %q not to be scanned for operations", args)
+		}
+		endSpan(nil)
+	}
+}
+
+func TestOperationSpans_longLines(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	ops := &operationSpans{
+		ctx:    context.Background(),
+		tracer: tp.Tracer("test"),
+		spans:  map[string]trace.Span{},
+	}
+
+	long := `{"hook":{"resource":{"addr":"null_resource.long"},"action":"create"},"type":"apply_start","padding":"` +
+		strings.Repeat("x", 2*maxOperationMessageSize) + `"}` + "\n"
+	short := `{"hook":{"resource":{"addr":"null_resource.short"},"action":"create"},"type":"apply_start"}` + "\n"
+
+	for i := 0; i < len(long); i += 4096 {
+		_, err := ops.Write([]byte(long[i:min(i+4096, len(long))]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ops.buf) > maxOperationMessageSize+4096 {
+			t.Fatalf("expected buffer to be capped, got %d bytes", len(ops.buf))
+		}
+	}
+	_, err := ops.Write([]byte(short))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ops.end()
+
+	var names []string
+	for _, s := range exporter.GetSpans() {
+		names = append(names, s.Name)
+	}
+	if diff := cmp.Diff([]string{"create null_resource.short"}, names); diff != "" {
+		t.Fatalf("unexpected spans: %s", diff)
+	}
+}
@@ -20,27 +20,35 @@ func (tf *Terraform) Validate(ctx context.Context) (*tfjson.ValidateOutput, erro
 		return nil, fmt.Errorf("terraform validate -json was added in 0.12.0: %w", err)
 	}
 
-	cmd := tf.buildTerraformCmd(ctx, nil, "validate", "-no-color", "-json")
+	var ret tfjson.ValidateOutput
+	err = tf.withRetry(ctx, nil, "validate", func() error {
+		cmd := tf.buildTerraformCmd(ctx, nil, "validate", "-no-color", "-json")
 
-	var outBuf = bytes.Buffer{}
-	cmd.Stdout = &outBuf
+		var outBuf = bytes.Buffer{}
+		cmd.Stdout = &outBuf
 
-	err = tf.runTerraformCmd(ctx, cmd)
-	// TODO: this command should not exit 1 if you pass -json as its hard to differentiate other errors
-	if err != nil && cmd.ProcessState.ExitCode() != 1 {
-		return nil, err
-	}
+		err := tf.runTerraformCmd(ctx, cmd)
+		// TODO: this command should not exit 1 if you pass -json as its hard to differentiate other errors
+		if err != nil && cmd.ProcessState.ExitCode() != 1 {
+			return err
+		}
 
-	var ret tfjson.ValidateOutput
-	// TODO: ret.UseJSONNumber(true) validate output should support JSON numbers
-	jsonErr := json.Unmarshal(outBuf.Bytes(), &ret)
-	if jsonErr != nil {
-		// the original call was possibly bad, if it has an error, actually just return that
-		if err != nil {
-			return nil, err
+		ret = tfjson.ValidateOutput{}
+		// TODO: ret.UseJSONNumber(true) validate output should support JSON numbers
+		jsonErr := json.Unmarshal(outBuf.Bytes(), &ret)
+		if jsonErr != nil {
+			// the original call was possibly bad, if it has an error, actually just return that
+			if err != nil {
+				return err
+			}
+
+			return jsonErr
 		}
 
-		return nil, jsonErr
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &ret, nil
@@ -35,8 +35,10 @@ var (
 	tf1_4_0  = version.Must(version.NewVersion("1.4.0"))
 	tf1_5_0  = version.Must(version.NewVersion("1.5.0"))
 	tf1_6_0  = version.Must(version.NewVersion("1.6.0"))
+	tf1_7_0  = version.Must(version.NewVersion("1.7.0"))
 	tf1_9_0  = version.Must(version.NewVersion("1.9.0"))
 	tf1_10_0 = version.Must(version.NewVersion("1.10.0"))
+	tf1_12_0 = version.Must(version.NewVersion("1.12.0"))
 	tf1_13_0 = version.Must(version.NewVersion("1.13.0"))
 	tf1_14_0 = version.Must(version.NewVersion("1.14.0"))
 )
@@ -53,6 +55,7 @@ func (tf *Terraform) Version(ctx context.Context, skipCache bool) (tfVersion *ve
 		if err != nil {
 			return nil, nil, err
 		}
+		tf.knownVersion.Store(tf.execVersion)
 	}
 
 	return tf.execVersion, tf.provVersions, nil
@@ -0,0 +1,50 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+)
+
+// workingDirLocks holds a *sync.RWMutex per absolute working directory,
+// shared by all instances using that directory, e.g. clones returned by
+// ForWorkspace.
+var workingDirLocks sync.Map
+
+// workingDirLockKey is the context key marking commands run while holding the
+// exclusive lock of a working directory, see lockWorkingDir.
+type workingDirLockKey struct{}
+
+func (tf *Terraform) workingDirLock() *sync.RWMutex {
+	dir, err := filepath.Abs(tf.workingDir)
+	if err != nil {
+		dir = filepath.Clean(tf.workingDir)
+	}
+	l, _ := workingDirLocks.LoadOrStore(dir, &sync.RWMutex{})
+	return l.(*sync.RWMutex)
+}
+
+// lockWorkingDir prevents commands from running in the working directory
+// until the returned function is called, e.g. while it contains temporary
+// configuration which must not be loaded by other commands. Only commands run
+// with the returned context are not blocked.
+func (tf *Terraform) lockWorkingDir(ctx context.Context) (context.Context, func()) {
+	l := tf.workingDirLock()
+	l.Lock()
+	return context.WithValue(ctx, workingDirLockKey{}, l), l.Unlock
+}
+
+// rlockWorkingDir waits for any exclusive lock of the working directory to be
+// released, see lockWorkingDir, and prevents it from being acquired until the
+// returned function is called.
+func (tf *Terraform) rlockWorkingDir(ctx context.Context) func() {
+	l := tf.workingDirLock()
+	if ctx.Value(workingDirLockKey{}) == l {
+		return func() {}
+	}
+	l.RLock()
+	return l.RUnlock
+}
@@ -0,0 +1,43 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLockWorkingDir(t *testing.T) {
+	dir := t.TempDir()
+	tf := &Terraform{workingDir: dir}
+	// e.g. a clone returned by ForWorkspace
+	other := &Terraform{workingDir: dir + "/."}
+
+	ctx, unlock := tf.lockWorkingDir(context.Background())
+
+	// commands run while holding the lock are not blocked
+	tf.rlockWorkingDir(ctx)()
+	other.rlockWorkingDir(ctx)()
+
+	locked := make(chan struct{})
+	go func() {
+		other.rlockWorkingDir(context.Background())()
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("expected other instance to wait for the lock")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected other instance to acquire the lock once released")
+	}
+}
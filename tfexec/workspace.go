@@ -0,0 +1,71 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfexec
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// EnsureWorkspace selects the given workspace, creating it first if it does
+// not exist yet.
+//
+// On Terraform 1.4.0 and later this uses `terraform workspace select -or-create`,
+// on earlier versions the workspace is looked up via WorkspaceList and created
+// via WorkspaceNew if missing.
+func (tf *Terraform) EnsureWorkspace(ctx context.Context, workspace string) error {
+	err := tf.compatible(ctx, tf1_4_0, nil)
+	if err == nil {
+		return tf.WorkspaceSelect(ctx, workspace, OrCreate(true))
+	}
+	var mismatch *ErrVersionMismatch
+	if !errors.As(err, &mismatch) {
+		return err
+	}
+
+	workspaces, current, err := tf.WorkspaceList(ctx)
+	if err != nil {
+		return err
+	}
+
+	if current == workspace {
+		return nil
+	}
+
+	if slices.Contains(workspaces, workspace) {
+		return tf.WorkspaceSelect(ctx, workspace)
+	}
+
+	// workspace new also selects the created workspace
+	return tf.WorkspaceNew(ctx, workspace)
+}
+
+// WithWorkspace selects the given workspace (creating it if necessary, see
+// EnsureWorkspace), runs fn and then restores the previously selected
+// workspace, even if fn returns an error or panics.
+//
+// The previous workspace is restored even if ctx has been cancelled. Any
+// error from restoring is joined with the error returned by fn.
+func (tf *Terraform) WithWorkspace(ctx context.Context, workspace string, fn func() error) (err error) {
+	_, previous, err := tf.WorkspaceList(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = tf.EnsureWorkspace(ctx, workspace)
+	if err != nil {
+		return err
+	}
+
+	if previous != workspace {
+		// deferred, so that the workspace is also restored if fn panics
+		defer func() {
+			restoreErr := tf.WorkspaceSelect(context.WithoutCancel(ctx), previous)
+			err = errors.Join(err, restoreErr)
+		}()
+	}
+
+	return fn()
+}
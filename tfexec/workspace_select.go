@@ -5,10 +5,12 @@ package tfexec
 
 import (
 	"context"
+	"fmt"
 	"os/exec"
 )
 
 type workspaceSelectConfig struct {
+	orCreate     bool
 	reattachInfo ReattachInfo
 }
 
@@ -18,6 +20,10 @@ type WorkspaceSelectOption interface {
 	configureWorkspaceSelect(*workspaceSelectConfig)
 }
 
+func (opt *OrCreateOption) configureWorkspaceSelect(conf *workspaceSelectConfig) {
+	conf.orCreate = opt.orCreate
+}
+
 func (opt *ReattachOption) configureWorkspaceSelect(conf *workspaceSelectConfig) {
 	conf.reattachInfo = opt.info
 }
@@ -33,6 +39,10 @@ func (tf *Terraform) WorkspaceSelect(ctx context.Context, workspace string, opts
 }
 
 func (tf *Terraform) workspaceSelectCmd(ctx context.Context, workspace string, opts ...WorkspaceSelectOption) (*exec.Cmd, error) {
+	if tf.workspace != "" {
+		return nil, fmt.Errorf("workspace select is not supported when the workspace is pinned to %q via ForWorkspace", tf.workspace)
+	}
+
 	c := defaultWorkspaceSelectOptions
 
 	for _, o := range opts {
@@ -48,5 +58,17 @@ func (tf *Terraform) workspaceSelectCmd(ctx context.Context, workspace string, o
 		mergeEnv[reattachEnvVar] = reattachStr
 	}
 
-	return tf.buildTerraformCmd(ctx, mergeEnv, "workspace", "select", "-no-color", workspace), nil
+	args := []string{"workspace", "select", "-no-color"}
+
+	if c.orCreate {
+		err := tf.compatible(ctx, tf1_4_0, nil)
+		if err != nil {
+			return nil, fmt.Errorf("-or-create was added to workspace select in Terraform 1.4.0: %w", err)
+		}
+		args = append(args, "-or-create")
+	}
+
+	args = append(args, workspace)
+
+	return tf.buildTerraformCmd(ctx, mergeEnv, args...), nil
 }
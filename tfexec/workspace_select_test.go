@@ -32,6 +32,20 @@ func TestWorkspaceSelectCmd(t *testing.T) {
 		}, nil, workspaceSelectCmd)
 	})
 
+	t.Run("or create", func(t *testing.T) {
+		workspaceSelectCmd, err := tf.workspaceSelectCmd(context.Background(), "workspace-name", OrCreate(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertCmd(t, []string{
+			"workspace", "select",
+			"-no-color",
+			"-or-create",
+			"workspace-name",
+		}, nil, workspaceSelectCmd)
+	})
+
 	t.Run("reattach config", func(t *testing.T) {
 		workspaceSelectCmd, err := tf.workspaceSelectCmd(context.Background(), "workspace-name", Reattach(map[string]ReattachConfig{
 			"registry.terraform.io/hashicorp/examplecloud": {
@@ -58,3 +72,18 @@ func TestWorkspaceSelectCmd(t *testing.T) {
 		}, workspaceSelectCmd)
 	})
 }
+
+func TestWorkspaceSelectCmd_OrCreateUnsupported(t *testing.T) {
+	tf, err := NewTerraform(t.TempDir(), tfVersion(t, testutil.Latest_v1_3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// empty env, to avoid environ mismatch in testing
+	tf.SetEnv(map[string]string{})
+
+	_, err = tf.workspaceSelectCmd(context.Background(), "workspace-name", OrCreate(true))
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+}